@@ -0,0 +1,78 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+)
+
+// TableCell is the cell chosen from a table with SelectTableCell
+type TableCell struct {
+	Row    int // index of the row in the list
+	Column int // index of the column in the row
+	Value  any // value of the cell
+}
+
+// SelectTableCell presents a table of mixed data types for selection of a cell and returns the selected cell.
+// UP and DOWN move the row cursor, and LEFT and RIGHT move the column cursor in the row.
+// The cell under the cursors is highlighted with the Cell style of the theme.
+// Returns the cell with Row and Column of -1 and an error if:
+// - the provided slice is empty
+// - the keyboard event channel closes
+// - the user quits (q or Ctrl+C)
+func SelectTableCell(list [][]any) (TableCell, error) {
+	return SelectTableCellWith(list, SelectOptions{})
+}
+
+// SelectTableCellWithHeader works like SelectTableCell, with the header pinned at the top of the table.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableCellWithHeader(header []string, list [][]any) (TableCell, error) {
+	return SelectTableCellWith(list, SelectOptions{Header: header})
+}
+
+// SelectTableCellWith works like SelectTableCell, configured with the options.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableCellWith(list [][]any, opts SelectOptions) (TableCell, error) {
+	return SelectTableCellContext(context.Background(), list, opts)
+}
+
+// SelectTableCellContext works like SelectTableCellWith, and returns ctx.Err() if the context is done before the selection.
+func SelectTableCellContext(ctx context.Context, list [][]any, opts SelectOptions) (TableCell, error) {
+	none := TableCell{Row: -1, Column: -1}
+	if len(list) == 0 {
+		return none, fmt.Errorf("SelectTableCell: %w", ErrEmptyList)
+	}
+	t, err := newTable(opts.Header, list)
+	if err != nil {
+		return none, err
+	}
+	t.column = 0
+
+	indices, err := selectTable(ctx, t, opts, false)
+	if err != nil || indices == nil {
+		return none, err
+	}
+	row := list[indices[0]]
+	// a row may be shorter than the others without the header
+	if t.column >= len(row) {
+		return TableCell{Row: indices[0], Column: t.column}, nil
+	}
+	return TableCell{Row: indices[0], Column: t.column, Value: row[t.column]}, nil
+}
+
+// handleSpecial moves the column cursor with LEFT and RIGHT in the cell selection.
+// It returns true if the key is handled.
+func (t *table) handleSpecial(s *session, key KeyEvent) bool {
+	switch key.Special {
+	case LEFT:
+		if t.column > 0 {
+			t.column--
+		}
+	case RIGHT:
+		if t.column < t.columns()-1 {
+			t.column++
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+)
+
+func TestSelectTableCell(t *testing.T) {
+	header := []string{"HOST", "IP", "PORT"}
+	rows := [][]any{
+		{"web", "10.0.0.1", 80},
+		{"db", "10.0.0.2", 5432},
+	}
+	// DOWN, RIGHT three times (stops at the last column), LEFT, ENTER
+	keys := [][]byte{{0x1b, '[', 'B'}, {0x1b, '[', 'C'}, {0x1b, '[', 'C'}, {0x1b, '[', 'C'}, {0x1b, '[', 'D'}, {0x0a}}
+	cell, out := selectWithOutput(t, keys, func() (select5.TableCell, error) {
+		return select5.SelectTableCellWithHeader(header, rows)
+	})
+	if cell.Row != 1 || cell.Column != 1 || cell.Value != "10.0.0.2" {
+		t.Fatalf("Expected the IP of db to be selected, got %+v", cell)
+	}
+	for _, s := range []string{"column: HOST", "column: IP", "column: PORT"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+}
+
+func TestSelectTableCell_Highlight(t *testing.T) {
+	theme := select5.MonochromeTheme
+	theme.Cell = select5.Style{Italic: true}
+	keys := [][]byte{{0x1b, '[', 'C'}, {0x0a}}
+	cell, out := selectWithOutput(t, keys, func() (select5.TableCell, error) {
+		return select5.SelectTableCellWith([][]any{{"a", "b"}}, select5.SelectOptions{Theme: &theme})
+	})
+	if cell.Value != "b" {
+		t.Fatalf("Expected 'b' to be selected, got %+v", cell)
+	}
+	// the cell style is started after the reset, and the row style follows the cell
+	if !strings.Contains(out, "\x1b[0m\x1b[3mb\x1b[0m\x1b[7m") {
+		t.Errorf("expected the highlighted cell in the output %q", out)
+	}
+}
+
+func TestSelectTableCell_EmptyList(t *testing.T) {
+	cell, err := select5.SelectTableCell(nil)
+	if !errors.Is(err, select5.ErrEmptyList) {
+		t.Fatalf("expected ErrEmptyList, got %v", err)
+	}
+	if cell.Row != -1 || cell.Column != -1 {
+		t.Errorf("expected no cell, got %+v", cell)
+	}
+}
@@ -0,0 +1,78 @@
+package select5
+
+import "context"
+
+// Answers of the confirmation, in the order of the choices on the line
+var confirmAnswers = []string{"Yes", "No"}
+
+// Confirm asks a yes/no question on one line below the cursor, and returns the answer.
+// 'y' and 'n' answer at once, LEFT, RIGHT, UP and DOWN toggle the choice, and ENTER accepts the choice, which is def at first.
+// The screen is not cleared, and the line is erased after the answer.
+// Returns an error if:
+// - the keyboard event channel closes
+// - the user quits (q or ESC for ErrCanceled, Ctrl+C for ErrInterrupted)
+func Confirm(question string, def bool) (bool, error) {
+	return ConfirmContext(context.Background(), question, def)
+}
+
+// ConfirmContext works like Confirm, and returns ctx.Err() if the context is done before the answer.
+func ConfirmContext(ctx context.Context, question string, def bool) (bool, error) {
+	s := newSession(confirmAnswers)
+	s.opts = SelectOptions{Screen: InlineScreen, Height: 1}
+	s.handleKey = handleConfirmKey
+	s.handleSpecial = handleConfirmSpecial
+	if def {
+		s.start(0)
+	} else {
+		s.start(1)
+	}
+	render := func(s *session) error {
+		renderConfirm(s, question, def)
+		return nil
+	}
+	indices, err := s.run(ctx, render)
+	if err != nil {
+		return false, err
+	}
+	return indices[0] == 0, nil
+}
+
+// handleConfirmKey answers the question with 'y' or 'n'.
+// It returns true if the key is handled.
+func handleConfirmKey(s *session, key KeyEvent) bool {
+	switch key.Key {
+	case 'y', 'Y':
+		s.moveTo(0)
+	case 'n', 'N':
+		s.moveTo(1)
+	default:
+		return false
+	}
+	s.finish = true
+	return true
+}
+
+// handleConfirmSpecial toggles the choice with LEFT and RIGHT, like UP and DOWN.
+// It returns true if the key is handled.
+func handleConfirmSpecial(s *session, key KeyEvent) bool {
+	switch key.Special {
+	case LEFT, RIGHT:
+		s.cursor = 1 - s.cursor
+	default:
+		return false
+	}
+	return true
+}
+
+// renderConfirm draws the question with the hint of the default answer, and the choices with the one under the cursor highlighted
+func renderConfirm(s *session, question string, def bool) {
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	line := question + " " + hint
+	for i, answer := range confirmAnswers {
+		line += " " + s.itemStyle(i, i).render(" "+answer+" ", s.profile)
+	}
+	s.draw([]string{line})
+}
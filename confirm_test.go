@@ -0,0 +1,66 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+)
+
+func confirmWithOutput(t *testing.T, keys [][]byte, def bool) (bool, string, error) {
+	return selectWithError(t, keys, func() (bool, error) {
+		return select5.Confirm("Continue?", def)
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     [][]byte
+		def      bool
+		expected bool
+	}{
+		{"enter accepts true", [][]byte{{0x0a}}, true, true},
+		{"enter accepts false", [][]byte{{0x0a}}, false, false},
+		{"y", [][]byte{{'y'}}, false, true},
+		{"N", [][]byte{{'N'}}, true, false},
+		{"right toggles", [][]byte{{0x1b, '[', 'C'}, {0x0a}}, true, false},
+		{"left toggles", [][]byte{{0x1b, '[', 'D'}, {0x0a}}, false, true},
+		{"down twice", [][]byte{{0x1b, '[', 'B'}, {0x1b, '[', 'B'}, {0x0a}}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer, _, err := confirmWithOutput(t, tt.keys, tt.def)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if answer != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, answer)
+			}
+		})
+	}
+}
+
+func TestConfirm_Inline(t *testing.T) {
+	_, out, _ := confirmWithOutput(t, [][]byte{{0x0a}}, false)
+	if !strings.Contains(out, "Continue? [y/N]") {
+		t.Errorf("expected the question with the hint in the output %q", out)
+	}
+	if strings.Contains(out, select5.ClearScreen) {
+		t.Errorf("expected the screen not to be cleared, got %q", out)
+	}
+	if strings.Contains(out, "\r\n") {
+		t.Errorf("expected one line, got %q", out)
+	}
+}
+
+func TestConfirm_Cancel(t *testing.T) {
+	_, _, err := confirmWithOutput(t, [][]byte{{0x1b}, {}}, true)
+	if !errors.Is(err, select5.ErrCanceled) {
+		t.Fatalf("expected ErrCanceled, got %v", err)
+	}
+	_, _, err = confirmWithOutput(t, [][]byte{{0x03}}, true)
+	if !errors.Is(err, select5.ErrCanceled) || !errors.Is(err, select5.ErrInterrupted) {
+		t.Fatalf("expected ErrInterrupted, got %v", err)
+	}
+}
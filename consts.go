@@ -2,18 +2,25 @@ package select5
 
 // Terminal control escape sequences
 const (
-	ClearScreen           = "\x1b[2J"     // Clear entire screen use with print functions
-	ClearScreenFromCursor = "\x1b[J"      // Clear all character after the cursor in the current line
-	ClearLine             = "\x1b[2K"     // Clear current line with print functions
-	ClearLineFromCursor   = "\x1b[K"      // Clear all character after the cursor in the current line
-	ResetCursor           = "\x1b[H"      // Move cursor to top-left cursor position
-	CursorUp              = "\x1b[1A"     // Move cursor up one line
-	CursorDown            = "\x1b[1B"     // Move cursor down one line
-	CursorRight           = "\x1b[1C"     // Move cursor right one character
-	CursorLeft            = "\x1b[1D"     // Move cursor left one character
-	HideCursor            = "\x1b[?25l"   // Hide cursor with print functions
-	ShowCursor            = "\x1b[?25h"   // Show cursor with print functions
-	MoveTo                = "\x1b[%d;%dH" // Move cursor to position with fmt.Printf
+	ClearScreen           = "\x1b[2J"                // Clear entire screen use with print functions
+	ClearScreenFromCursor = "\x1b[J"                 // Clear all character after the cursor in the current line
+	ClearLine             = "\x1b[2K"                // Clear current line with print functions
+	ClearLineFromCursor   = "\x1b[K"                 // Clear all character after the cursor in the current line
+	ResetCursor           = "\x1b[H"                 // Move cursor to top-left cursor position
+	CursorUp              = "\x1b[1A"                // Move cursor up one line
+	CursorDown            = "\x1b[1B"                // Move cursor down one line
+	CursorRight           = "\x1b[1C"                // Move cursor right one character
+	CursorLeft            = "\x1b[1D"                // Move cursor left one character
+	HideCursor            = "\x1b[?25l"              // Hide cursor with print functions
+	ShowCursor            = "\x1b[?25h"              // Show cursor with print functions
+	MoveTo                = "\x1b[%d;%dH"            // Move cursor to position with fmt.Printf
+	MoveUp                = "\x1b[%dA"               // Move cursor up the lines with fmt.Printf
+	EnterAltScreen        = "\x1b[?1049h"            // Switch to the alternate screen, saving the current screen
+	ExitAltScreen         = "\x1b[?1049l"            // Switch back to the saved screen
+	DisableAutoWrap       = "\x1b[?7l"               // Cut the line at the right edge of the terminal, instead of wrapping
+	EnableAutoWrap        = "\x1b[?7h"               // Wrap the line at the right edge of the terminal
+	EnableMouse           = "\x1b[?1000h\x1b[?1006h" // Report the mouse buttons and the wheel in the SGR mode
+	DisableMouse          = "\x1b[?1006l\x1b[?1000l" // Stop reporting the mouse
 
 	BS       = 0x08
 	ENTER    = 0x0a
@@ -29,6 +36,7 @@ const (
 	HOME     = 0x1b5b48
 	PAGEUP   = 0x1b357e
 	PAGEDOWN = 0x1b367e
+	MOUSE    = 0x1b5b3c // mouse event in the SGR mode
 
 	CtrlA = 0x01
 	CtrlB = 0x01
@@ -0,0 +1,223 @@
+package select5_test
+
+import (
+	"context"
+	"errors"
+	"github.com/g1eng/select5"
+	"golang.org/x/sys/unix"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSelectStringContext(t *testing.T) {
+	list := []string{"staging", "production"}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := select5.SelectStringContext(ctx, list, select5.SelectOptions{})
+		errCh <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for cancellation")
+	}
+
+	// the keys are delivered to the next selection after the cancellation
+	resultCh := make(chan string)
+	go func() {
+		result, err := select5.SelectString(list)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x0a})
+
+	select {
+	case result := <-resultCh:
+		if result != "production" {
+			t.Fatalf("Expected 'production' to be selected, got '%s'", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectStringContext_KeepsInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := select5.SelectStringContext(ctx, []string{"a", "b"}, select5.SelectOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// the byte typed after the selection is read by the caller, not by the stopped key capture
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte("y"))
+	readCh := make(chan []byte)
+	go func() {
+		buf := make([]byte, 1)
+		n, _ := r.Read(buf)
+		readCh <- buf[:n]
+	}()
+	select {
+	case b := <-readCh:
+		if string(b) != "y" {
+			t.Fatalf("expected the byte to be read after the selection, got %q", b)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for the byte")
+	}
+}
+
+func TestSelectTableRowContext_Deadline(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	errCh := make(chan error)
+	go func() {
+		_, err := select5.SelectTableRowContext(ctx, [][]any{{"a", 1}, {"b", 2}}, select5.SelectOptions{})
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for the deadline")
+	}
+}
+
+func TestSelector_SelectContext_IdleTimeout(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	s := select5.Selector{
+		Data: []string{"small", "medium", "large"},
+		Options: select5.SelectOptions{
+			DefaultValue: "medium",
+			IdleTimeout:  300 * time.Millisecond,
+		},
+	}
+	resultCh := make(chan any)
+	go func() {
+		result, err := s.SelectContext(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	// a key press restarts the idle timer, and does not change the default item
+	time.Sleep(200 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+
+	select {
+	case result := <-resultCh:
+		t.Fatalf("expected no selection before the idle timeout, got %v", result)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	select {
+	case result := <-resultCh:
+		if result != "medium" {
+			t.Fatalf("Expected 'medium' to be accepted, got '%v'", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for the idle timeout")
+	}
+}
+
+func TestSelectStringsContext_IdleTimeoutMarked(t *testing.T) {
+	opts := select5.SelectOptions{IdleTimeout: 300 * time.Millisecond}
+	keys := [][]byte{{' '}, {0x1b, '[', 'B'}, {0x1b, '[', 'B'}, {' '}}
+	got, _ := selectWithOutput(t, keys, func() ([]string, error) {
+		return select5.SelectStringsContext(context.Background(), []string{"default", "kube-system", "monitoring"}, opts)
+	})
+	if !reflect.DeepEqual(got, []string{"default", "monitoring"}) {
+		t.Fatalf("Expected the marked items to be accepted, got %v", got)
+	}
+}
+
+func TestSelectStringContext_RestoresTerminal(t *testing.T) {
+	master, slave := openPty(t)
+	// the output is drawn to the terminal, and drained from the master
+	go io.Copy(io.Discard, master)
+
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = slave, slave
+	defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+	for i := 0; i < 30; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := select5.SelectStringContext(ctx, []string{"a", "b"}, select5.SelectOptions{})
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+		termios, err := unix.IoctlGetTermios(int(slave.Fd()), unix.TCGETS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if termios.Lflag&(unix.ECHO|unix.ICANON) != unix.ECHO|unix.ICANON {
+			t.Fatalf("the terminal is left in raw mode after the selection %d", i)
+		}
+	}
+}
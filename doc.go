@@ -71,6 +71,41 @@ package select5
 //
 // You can apply additional type casting for the interface (a.k.a. `any` type) results.
 //
+// Data may also be a list of other values ([]any like the one of NewSelectorFrom, or a typed slice like []int, []float64 or []bool),
+// which returns the original element, or a map, which is shown as a table of the keys and the values sorted by the keys and returns the KeyValue of the entry.
+//
+//	port, _ := (&select5.Selector{Data: []int{80, 443, 8080}}).Select() // int
+//	entry, _ := (&select5.Selector{Data: map[string]float64{"cpu": 0.5, "mem": 0.8}}).Select()
+//	fmt.Println(entry.(select5.KeyValue).Key)
+//
+// For a table, Header is rendered as the header row, which is pinned at the top while the rows are scrolled and cannot be selected.
+// It must have the same number of columns as the rows. SelectTableRowWithHeader and SelectTableRowsWithHeader do the same without Selector.
+//
+// # Typed Selection
+//
+// SelectOf and SelectTableOf select from a slice of any type, without converting it to []string or [][]any.
+// They return the selected value and its index.
+//
+//	inst, i, err := select5.SelectOf(instances, func(i Instance) string { return i.Name })
+//
+//	inst, i, err := select5.SelectTableOf(instances,
+//		func(i Instance) any { return i.Name },
+//		func(i Instance) any { return i.Price },
+//	)
+//
+// # Struct Selection
+//
+// SelectStructRow (and Selector with a slice of structs or struct pointers in Data) shows the structs as a table.
+// The columns are taken from the exported fields, and configured with the select5 struct tag:
+//
+//	type Instance struct {
+//		Name  string  `select5:"NAME,width=20"` // header name and maximum width
+//		Note  *string `select5:",omitempty"`    // omitted if the field is empty in all the rows
+//		Token string  `select5:"-"`             // not shown
+//	}
+//
+//	inst, i, err := select5.SelectStructRow(instances)
+//
 // # Type Helpers for primitives
 //
 // The package includes helper functions to safely extract and convert values from the `any` type:
@@ -84,6 +119,13 @@ package select5
 // 	// Extract bool value
 // 	active, err := select5.GetB(selectedRow[3])
 //
+// GetV (and GetVP with pointers) converts a cell value to the text shown in tables.
+// It supports strings, ints, unsigned ints, floats and bools (including named types of them, like type Status string),
+// nil and nil pointers (an empty cell), time.Time (RFC 3339), time.Duration, json.Number,
+// and the values implementing error, fmt.Stringer or encoding.TextMarshaler.
+// CheckPrimitive classifies the values the same way: for example time.Duration as IsInt64 and time.Time as IsString.
+// A cell of an unsupported type ends the selection with ErrUnsupportedType.
+//
 // # Builtin Type Detector for `Selector`
 //
 // The `Selector` implements type detector in `Type()`, which returns type information in byte expression.
@@ -114,6 +156,18 @@ package select5
 // (If one or more types have been detected in the data, the result will be a logical sum by IsAny 0x7f).
 // You can implement type switcher for various data structure, using this mechanism.
 //
+// The bitmask cannot tell int from int8, and it is the union of all the columns.
+// Schema() returns the type of each column instead: the Kind of the values (a distinct kind for every Go numeric type,
+// KindTime, KindDuration and KindText for the values shown with their methods), KindMixed with the detected Kinds,
+// and whether the column is Nullable (nil, a nil pointer or a short row) or has a Pointer.
+// A list (a slice of any type) has one column, a map has the key and the value columns, and a slice of structs has the columns of the fields.
+// Type() is derived from the schema.
+//
+//	schema := (&select5.Selector{Header: header, Data: rows}).Schema()
+//	for _, c := range schema.Columns {
+//		fmt.Printf("%s: %s (nullable: %v, mixed: %v)\n", c.Name, c.Kind, c.Nullable, c.Mixed())
+//	}
+//
 // # Terminal Control
 //
 // The package provides constants for terminal control operations:
@@ -130,16 +184,303 @@ package select5
 // # Keyboard Navigation
 //
 // - Up/Down arrows: Move selection
+// - PageUp/PageDown: Move selection by a page
+// - Home/End: Move selection to the first or last item
+// - 's' (tables): Sort by the next column, 'r' (tables): Reverse the sort order
+// - '/' (tables): Filter the rows with an expression
 // - Enter: Confirm selection
-// - 'q' or Ctrl+C: Quit without selection
+// - 'q' or Ctrl+C: Quit without selection (ESC or Ctrl+C for SelectString)
+//
+// Long lists are scrolled within the terminal height, and the position of the cursor is shown in the last line (like "12/200").
+//
+// # Table Sorting
+//
+// In table selection, s sorts the rows by the next column (back to the original order after the last column), and r reverses the order.
+// The sorted column is marked with ▲ or ▼ in the header.
+// The cells are compared by the type detected by CheckPrimitive: numbers numerically, false before true,
+// and strings case-insensitively with the numbers in them in the numeric order (web-9 before web-10).
+// The returned row is the original row.
+//
+// # Table Filter
+//
+// In table selection, / opens the filter prompt in the status line, which is ended with Enter (keeping the filter) or ESC (clearing it).
+// The filter is a list of conditions separated by spaces, and the rows which satisfy all of them are shown:
+//
+//	price>3.5 active=true name~cis
+//
+// - A column is named by the header (case-insensitive) or by the column number starting from 1.
+// - The operators are =, !=, >, >=, <, <=, ~ (contains) and !~ (does not contain).
+// - The value is compared by the type of the column detected by CheckPrimitive; use double quotes for a value with spaces.
+// - A word without an operator matches the rows containing it in any cell.
+//
+// The matched cells are highlighted. An invalid filter is reported in the status line, and all the rows are shown.
+//
+// # Fuzzy Search for Lists
+//
+// SelectString narrows the list while you type.
+// Printable keys build a query shown in the first line, BS/DEL edits the query and ESC clears it.
+// Matched characters are highlighted, and the best matches are listed first.
+// The returned value is always the original list entry.
+//
+// # Multiple Selection
+//
+// SelectStrings and SelectTableRows let the user pick several items.
+// Space marks or unmarks the item under the cursor, and 'a' marks or unmarks all the items.
+// Enter returns the marked items in the original order (or the item under the cursor, if nothing is marked).
+// In SelectStrings, '/' starts the fuzzy search, which is ended with Enter or ESC.
+//
+//	namespaces, err := select5.SelectStrings([]string{"default", "kube-system", "monitoring"})
+//
+// Selector supports it with the Multi field, and Select() returns []string or [][]any.
+//
+// # Selection Options
+//
+// The functions with the With suffix (SelectStringWith, SelectStringsWith, SelectTableRowWith, SelectTableRowsWith)
+// and the Options field of Selector take SelectOptions:
+//
+//	size, err := select5.SelectStringWith(sizes, select5.SelectOptions{
+//		DefaultValue: "medium",            // or Default: 1 (the index)
+//		Prompt:       "Instance size?",    // shown above the list
+//		Footer:       "↑/↓: move, Enter: select",
+//		NoWrap:       true,                // stop the cursor at the ends of the list
+//		Cursor:       "➜ ",                // "> " by default
+//	})
+//
+// For tables, Header is the header row, and DefaultValue is compared with the rows by reflect.DeepEqual.
+// The zero value of SelectOptions is the default behavior.
+//
+// # Context and Timeouts
+//
+// SelectStringContext, SelectStringsContext, SelectTableRowContext, SelectTableRowsContext and Selector.SelectContext
+// abort the selection when the context is done: the terminal is restored, the key reading is stopped, and ctx.Err() is returned.
+// With IdleTimeout in SelectOptions, the default item is accepted if no key is pressed for the duration.
+// In the multiple selection, the marked items are accepted instead, if any.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+//	defer cancel()
+//
+//	env, err := select5.SelectStringContext(ctx, []string{"staging", "production"}, select5.SelectOptions{
+//		DefaultValue: "staging",
+//		IdleTimeout:  30 * time.Second,
+//	})
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		log.Fatal("no answer")
+//	}
+//
+// # Streaming Sources
+//
+// Selector.Data may also be a streaming source, which is shown right away and grows while the items arrive:
+//
+// - <-chan string and iter.Seq[string] for a list
+// - <-chan []any and iter.Seq[[]any] for a table
+// - iter.Seq2[string, error] and iter.Seq2[[]any, error] for a pager which may fail
+//
+// A loading indicator is shown in the status line until the source is exhausted, and the user can select an item before it.
+// The reading of the source is stopped after the selection (a sequence is stopped, and a channel is no longer received).
+// An error from the source is shown in the status line, and ErrEmptyList is returned if the source has no item.
+//
+//	files := make(chan string)
+//	go func() {
+//		defer close(files)
+//		filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+//			files <- path
+//			return nil
+//		})
+//	}()
+//	file, err := (&select5.Selector{Data: (<-chan string)(files)}).Select()
+//
+// # Menu Tree
+//
+// SelectTree walks down a tree of MenuNode in one screen, such as cluster → namespace → pod.
+// RIGHT or Enter opens a branch (shown with a trailing /), and LEFT or ESC goes back to the parent.
+// The path is shown in the breadcrumb line, and typing narrows the current level with the fuzzy search.
+// Enter on a leaf returns the path of the selected nodes, and ESC at the top quits with ErrCanceled.
+//
+//	root := &select5.MenuNode{Label: "clusters", Children: []*select5.MenuNode{
+//		{Label: "prod", Load: func() ([]*select5.MenuNode, error) {
+//			return listNamespaces("prod") // called once, when "prod" is opened
+//		}},
+//	}}
+//	path, err := select5.SelectTree(root)
+//	// path[0].Label == "prod", path[1].Label == "kube-system", ...
+//
+// SelectTreeContext takes the context and SelectOptions. An error of Load is shown in the status line.
+// The IdleTimeout option accepts the default child of the root, and it is ignored if the child is a branch.
+//
+// # Screen Modes
+//
+// By default, the selection clears the screen and draws from the top-left corner.
+// The Screen option of SelectOptions changes it:
+//
+// - InlineScreen draws just below the cursor with the lines it needs (up to Height lines, if it is set), and erases itself on exit.
+//   The previous output of the command stays on the screen.
+// - AltScreen draws in the alternate screen (\x1b[?1049h), and the prior contents of the terminal come back on exit.
+//
+// With the Summary option, a line like ✔ picked: foo is left after the selection.
+//
+//	env, err := select5.SelectStringWith(envs, select5.SelectOptions{
+//		Screen:  select5.InlineScreen,
+//		Height:  10,
+//		Summary: true,
+//	})
+//
+// # Themes
+//
+// The Theme option of SelectOptions sets the appearance of lists and tables:
+// the cursor glyph, the styles of the selected, unselected and disabled items, the matched text, and the header and the border of tables.
+// A Style has the foreground and background colors and the attributes (bold, dim, italic, underline and reverse).
+//
+//	theme := select5.DefaultTheme
+//	theme.Cursor = "➜ "
+//	theme.Selected = select5.Style{Fg: select5.RGBColor(255, 135, 0), Bold: true}
+//	theme.Border = select5.Style{Fg: select5.IndexedColor(240)}
+//
+//	row, err := select5.SelectTableRowWith(rows, select5.SelectOptions{
+//		Theme:    &theme,
+//		Disabled: []int{2}, // shown with the Disabled style, and cannot be chosen
+//	})
+//
+// Colors are given with BasicColor (16 colors), IndexedColor (256 colors) or RGBColor (truecolor),
+// and downgraded to the nearest color the terminal supports (detected from COLORTERM and TERM).
+// If NO_COLOR is set, colors are not used, but the other attributes are kept.
+// The built-in themes are DefaultTheme, HighContrastTheme and MonochromeTheme.
+//
+// # Preview Pane
+//
+// The Preview option shows the details of the item under the cursor in a pane right of or below the list or table.
+// PreviewWriter streams the preview into an io.Writer instead, and its context is canceled when the cursor moves.
+// The preview is started after a short pause of the cursor (debounced), so a slow preview does not block the navigation.
+//
+//	file, err := select5.SelectStringWith(files, select5.SelectOptions{
+//		Preview: func(item any) string {
+//			data, _ := os.ReadFile(item.(string))
+//			return string(data)
+//		},
+//	})
+//
+//	pod, err := select5.SelectTableRowWith(pods, select5.SelectOptions{
+//		PreviewPosition: select5.PreviewBottom,
+//		PreviewSize:     40, // percent of the terminal height
+//		PreviewWriter: func(ctx context.Context, item any, w io.Writer) error {
+//			cmd := exec.CommandContext(ctx, "kubectl", "logs", item.([]any)[0].(string))
+//			cmd.Stdout = w
+//			return cmd.Run()
+//		},
+//	})
+//
+// The item is the string of a list, the row of a table, the struct of Selector with a slice of structs, or the MenuNode of SelectTree.
+// With PreviewAuto (default), the pane is put right of the items if the terminal has 80 columns or more, and below them otherwise.
+// PreviewSize is the percentage of the terminal width (right) or height (bottom) for the pane, 50 by default.
+//
+// # Wide Characters and Truncation
+//
+// Each item and each table row takes exactly one line of the screen.
+// The width of the text is measured in terminal columns, so East Asian wide characters and emoji take two columns, and escape sequences (colors) take none.
+// A line wider than the screen is truncated with Ellipsis ("…"), and the columns of a table are shrunk in proportion to their widths to fit the screen.
+// The ColumnWidths option sets the maximum width of each column, as the width tag of a struct field does.
+//
+//	row, err := select5.SelectTableRowWith(rows, select5.SelectOptions{
+//		ColumnWidths: []int{20, 0, 10}, // 0 for no limit
+//	})
+//
+// The selected item is returned as is, without truncation.
+//
+// # Mouse
+//
+// The Mouse option enables the mouse reporting of the terminal (SGR mode) while the selection is shown.
+// A click moves the cursor to the item, a double click accepts it like ENTER, and the wheel scrolls the page.
+// Clicks are handled in FullScreen and AltScreen, and the wheel in all the screen modes.
+// The mouse reporting is turned off again when the selection ends, whether it is accepted, canceled or interrupted.
+//
+//	item, err := select5.SelectStringWith(items, select5.SelectOptions{Mouse: true})
+//
+// CaptureKeyboardEvents delivers the mouse events as a KeyEvent with Special set to MOUSE.
+// The Mouse field has the button (or the wheel), the position and whether the button is released,
+// and the modifiers are set in Ctrl, Alt and Shift.
+//
+// # Cell Selection
+//
+// SelectTableCell chooses a cell of a table instead of a row.
+// LEFT and RIGHT move the column cursor, and the cell under the cursors is highlighted over the row with the Cell style of the theme.
+// The current column is shown in the status line, and sorting and filtering work as in the row selection.
+//
+//	cell, err := select5.SelectTableCellWithHeader([]string{"HOST", "IP"}, hosts)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("row %d, column %d: %v\n", cell.Row, cell.Column, cell.Value)
+//
+// Row is the index of the row in the original list, and Value is the original value of the cell.
+//
+// # Column Formats
+//
+// The ColumnFormats option sets the format of each column of a table: the precision of floats, the thousands separator of numbers,
+// the alignment, the glyphs of booleans, or a custom formatter.
+// Numbers are aligned to the right and the others to the left by default (AlignAuto).
+//
+//	row, err := select5.SelectTableRowWith(rows, select5.SelectOptions{
+//		Header: []string{"NAME", "SIZE", "RATIO", "ACTIVE", "UPDATED"},
+//		ColumnFormats: []select5.ColumnFormat{
+//			{},                               // as is
+//			{Thousands: ","},                 // 1,234,567
+//			{Precision: 2},                   // 0.50, or NoDecimals for 1
+//			{True: "yes", False: "no", Align: select5.AlignCenter},
+//			{Format: func(v any) string { return v.(time.Time).Format(time.DateOnly) }},
+//		},
+//	})
+//
+// The formats change only the text shown in the table; the returned rows have the original values, and the filter and the sorting use them.
+// The values shown with their methods (like time.Duration and the types with String) are not formatted as numbers.
+//
+// # Cell Editing
+//
+// EditTable shows a table like SelectTableCell and lets the user fix the values of the cells.
+// e opens a single-line editor over the cell under the cursors: LEFT, RIGHT, HOME, END and Backspace edit the text,
+// ENTER stores it in the cell, and ESC discards it. ENTER outside the editor returns the edited table.
+//
+//	edited, err := select5.EditTableWithHeader([]string{"KEY", "VALUE", "ENABLED"}, config)
+//	if err != nil {
+//		return err
+//	}
+//	save(edited)
+//
+// The text is parsed into the type of the original value (string, bool, integers, floats, time.Time, time.Duration,
+// named types of them, and pointers to them). A text which cannot be parsed is shown as an error in the status line,
+// and the editor stays open. An empty text is a nil pointer for a pointer cell.
+// The given list is not modified; the rows are copied, and an edited pointer cell gets a new pointer.
+//
+// # Confirmation
+//
+// Confirm asks a yes/no question on one line below the cursor, without clearing the screen.
+// y and n answer at once, the arrow keys toggle the choice, and ENTER accepts it; the default answer is chosen at first
+// and shown in capital in the hint ([Y/n] or [y/N]). The line is erased after the answer.
+//
+//	ok, err := select5.Confirm("Delete 3 files?", false)
+//	if err != nil {
+//		return err // ErrCanceled for ESC or q, ErrInterrupted for Ctrl+C
+//	}
+//	if ok {
+//		deleteFiles()
+//	}
 //
 // # Error Handling
 //
-// All selection functions return appropriate errors that should be checked:
-// - Empty lists
-// - Type conversion failures
-// - Keyboard event channel closure
-// - Interrupted selection
+// All selection functions return appropriate errors that should be checked.
+// The following errors are wrapped with the context, and can be checked with errors.Is:
+// - ErrCanceled: the user quits the selection (q or ESC)
+// - ErrInterrupted: Ctrl+C or a signal (it is also ErrCanceled)
+// - ErrEmptyList: no item to select
+// - ErrNotTerminal: the terminal cannot be put in raw mode, or the input is closed (EOF of stdin)
+// - ErrUnsupportedType: the data cannot be shown or selected
+//
+//	selected, err := select5.SelectString(list)
+//	if errors.Is(err, select5.ErrCanceled) {
+//		return // the user changed their mind
+//	} else if err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Editor.Edit returns the text so far and ErrInterrupted for Ctrl+C or a signal.
 //
 // # Text Editor
 //
@@ -155,7 +496,10 @@ package select5
 //
 //func main() {
 //	ed := select5.NewEditor()
-//	res := ed.Edit()
+//	res, err := ed.Edit()
+//	if err != nil {
+//		panic(err)
+//	}
 //	t, err := os.CreateTemp(".", "result-*.txt")
 //	defer t.Close()
 //	if err != nil {
@@ -0,0 +1,295 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// EditTable presents a table of mixed data types for review and editing, and returns the edited table.
+// UP and DOWN move the row cursor, and LEFT and RIGHT move the column cursor in the row.
+// 'e' opens a single-line editor over the cell under the cursors, and ENTER in the editor stores the text in the cell.
+// The text is parsed into the type of the original value (string, bool, int, uint, float, time.Time, time.Duration
+// or a pointer to them), and a text which cannot be parsed is shown as an error in the status line.
+// An empty text is a nil pointer for a pointer cell. ESC in the editor discards the text.
+// ENTER without the editor returns the table. The list is not modified, and an edited pointer cell gets a new pointer.
+// Returns an error if:
+// - the provided slice is empty
+// - the keyboard event channel closes
+// - the user quits (q or Ctrl+C)
+func EditTable(list [][]any) ([][]any, error) {
+	return EditTableWith(list, SelectOptions{})
+}
+
+// EditTableWithHeader works like EditTable, with the header pinned at the top of the table.
+// Returns an error if the header has a different number of columns from a row.
+func EditTableWithHeader(header []string, list [][]any) ([][]any, error) {
+	return EditTableWith(list, SelectOptions{Header: header})
+}
+
+// EditTableWith works like EditTable, configured with the options.
+// Returns an error if the header has a different number of columns from a row.
+func EditTableWith(list [][]any, opts SelectOptions) ([][]any, error) {
+	return EditTableContext(context.Background(), list, opts)
+}
+
+// EditTableContext works like EditTableWith, and returns ctx.Err() if the context is done before the table is returned.
+func EditTableContext(ctx context.Context, list [][]any, opts SelectOptions) ([][]any, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("EditTable: %w", ErrEmptyList)
+	}
+	rows := make([][]any, len(list))
+	for i, row := range list {
+		rows[i] = append([]any{}, row...)
+	}
+	t, err := newTable(opts.Header, rows)
+	if err != nil {
+		return nil, err
+	}
+	t.column = 0
+	t.editable = true
+
+	if _, err := selectTable(ctx, t, opts, false); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// cellEditor keeps the text of the cell being edited (internal use)
+type cellEditor struct {
+	row    int     // original index of the row
+	column int     // index of the column
+	editor *Editor // single-line editor of the text, which writes nothing
+	err    error   // error of the last text which could not be parsed
+}
+
+// startEdit opens the editor over the cell under the cursors.
+// It returns false if no cell is under the cursors.
+func (t *table) startEdit(s *session) bool {
+	index := s.selected()
+	if index < 0 || t.column >= len(t.rows[index]) {
+		return false
+	}
+	text := editText(t.rows[index][t.column])
+	editor := &Editor{Out: io.Discard, Line: []string{text}}
+	editor.Cursor.X = len(text)
+	t.edit = &cellEditor{row: index, column: t.column, editor: editor}
+	return true
+}
+
+// handleEditKey opens the editor with 'e', and puts the printable keys in the editor while it is open.
+// It returns true if the key is handled.
+func (t *table) handleEditKey(s *session, key KeyEvent) bool {
+	if t.edit == nil {
+		if key.Key == 'e' {
+			return t.startEdit(s)
+		}
+		return t.handleKey(s, key)
+	}
+	c, err := key.Utf8Char()
+	if err != nil || len(c) == 0 {
+		return true
+	}
+	t.edit.editor.PutS(c)
+	return true
+}
+
+// handleEditSpecial handles the special keys in the editor while it is open,
+// and moves the column cursor otherwise. It returns true if the key is handled.
+func (t *table) handleEditSpecial(s *session, key KeyEvent) bool {
+	if t.edit == nil {
+		return t.handleSpecial(s, key)
+	}
+	e := t.edit.editor
+	switch key.Special {
+	case LEFT:
+		if !e.IsOnLineHead() {
+			e.Left()
+		}
+	case RIGHT:
+		e.Right()
+	case HOME:
+		e.Cursor.X = 0
+	case END:
+		e.Cursor.X = e.GetLineMaxX()
+	case BS, DEL:
+		e.PutBackspace()
+	case ENTER:
+		t.commitEdit(s)
+	case ESC:
+		t.edit = nil
+	}
+	// the other keys are ignored, so that the cursors stay on the cell
+	return true
+}
+
+// commitEdit stores the text of the editor in the cell and closes the editor.
+// If the text cannot be parsed, the editor is kept open with the error.
+func (t *table) commitEdit(s *session) {
+	c := t.edit
+	v, err := parseCell(c.editor.Line[0], t.rows[c.row][c.column], t.columnType(c.column))
+	if err != nil {
+		c.err = err
+		return
+	}
+	t.rows[c.row][c.column] = v
+	t.edit = nil
+	s.labels[c.row] = t.rowLabel(t.rows[c.row])
+	s.rearrange()
+}
+
+// columnType returns the type of the first non-nil value of the column, or nil if the column has no value
+func (t *table) columnType(column int) reflect.Type {
+	for _, row := range t.rows {
+		if column < len(row) && row[column] != nil {
+			return reflect.TypeOf(row[column])
+		}
+	}
+	return nil
+}
+
+// text returns the text of the editor with the character under the cursor shown in the caret style.
+// The restore style is started after the caret.
+func (c *cellEditor) text(caret, restore string) string {
+	line, x := c.editor.Line[0], c.editor.Cursor.X
+	char, rest := " ", ""
+	if x < len(line) {
+		_, size := utf8.DecodeRuneInString(line[x:])
+		char, rest = line[x:x+size], line[x+size:]
+	}
+	return line[:x] + caret + char + resetStyle + restore + rest
+}
+
+// editText returns the text of a cell value in the editor, which is parsed back into the same value.
+// A nil pointer is an empty text.
+func editText(v any) string {
+	r := reflect.ValueOf(v)
+	if !r.IsValid() || (r.Kind() == reflect.Pointer && r.IsNil()) {
+		return ""
+	}
+	if r.Kind() == reflect.Pointer {
+		r = r.Elem()
+	}
+	switch r.Type() {
+	case reflect.TypeFor[time.Time]():
+		return r.Interface().(time.Time).Format(time.RFC3339)
+	case reflect.TypeFor[time.Duration]():
+		return time.Duration(r.Int()).String()
+	}
+	// the methods like String are not used, since the text is parsed by the kind
+	switch r.Kind() {
+	case reflect.String:
+		return r.String()
+	case reflect.Bool:
+		return strconv.FormatBool(r.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(r.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(r.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(r.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(r.Float(), 'g', -1, 64)
+	}
+	text, _ := cellString(v)
+	return text
+}
+
+// parseCell parses the text into a value of the type of the original cell value.
+// A nil cell takes the type of the column, and the text is kept as a string if the column has no value.
+func parseCell(text string, orig any, column reflect.Type) (any, error) {
+	typ := reflect.TypeOf(orig)
+	if typ == nil {
+		typ = column
+	}
+	if typ == nil {
+		if text == "" {
+			return nil, nil
+		}
+		return text, nil
+	}
+	if typ.Kind() != reflect.Pointer {
+		v, err := parseValue(text, typ)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	if text == "" {
+		return reflect.Zero(typ).Interface(), nil
+	}
+	v, err := parseValue(text, typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	p := reflect.New(typ.Elem())
+	p.Elem().Set(v)
+	return p.Interface(), nil
+}
+
+// parseValue parses the text into a value of the type, which may be a named type of a basic kind
+func parseValue(text string, typ reflect.Type) (reflect.Value, error) {
+	v := reflect.New(typ).Elem()
+	invalid := func(err error) (reflect.Value, error) {
+		if ne, ok := err.(*strconv.NumError); ok {
+			err = ne.Err
+		}
+		return v, fmt.Errorf("invalid %s %q: %v", typ, text, err)
+	}
+	switch typ {
+	case reflect.TypeFor[time.Time]():
+		t, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return invalid(fmt.Errorf("not RFC 3339"))
+		}
+		v.Set(reflect.ValueOf(t))
+		return v, nil
+	case reflect.TypeFor[time.Duration]():
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return invalid(fmt.Errorf("not a duration"))
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return invalid(err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, typ.Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 10, typ.Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, typ.Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		v.SetFloat(f)
+	default:
+		return v, fmt.Errorf("cannot edit %s: %w", typ, ErrUnsupportedType)
+	}
+	return v, nil
+}
+
+// editing returns true if the cell of the original row index and the column is being edited
+func (t *table) editing(index, column int) bool {
+	return t.edit != nil && t.edit.row == index && t.edit.column == column
+}
@@ -0,0 +1,92 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEditTable(t *testing.T) {
+	header := []string{"HOST", "PORT", "TLS"}
+	rows := [][]any{
+		{"web", 80, false},
+		{"db", 5432, false},
+	}
+	// RIGHT to PORT, edit it with an invalid number, fix it, and edit TLS of the same row
+	keys := [][]byte{
+		{0x1b, '[', 'C'}, {'e'}, {0x7f, 0x7f}, []byte("8x"), {0x0a},
+		{0x7f}, []byte("080"), {0x0a},
+		{0x1b, '[', 'C'}, {'e'}, {0x7f, 0x7f, 0x7f, 0x7f, 0x7f}, []byte("true"), {0x0a},
+		{0x0a},
+	}
+	edited, out := selectWithOutput(t, keys, func() ([][]any, error) {
+		return select5.EditTableWithHeader(header, rows)
+	})
+	if edited[0][1] != 8080 || edited[0][2] != true {
+		t.Errorf("Expected the row of web to be edited, got %v", edited[0])
+	}
+	if rows[0][1] != 80 || rows[0][2] != false {
+		t.Errorf("Expected the list not to be modified, got %v", rows[0])
+	}
+	for _, s := range []string{"column: PORT  editing", `invalid int "8x"`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+}
+
+func TestEditTable_Pointers(t *testing.T) {
+	port, ratio := 80, 0.5
+	rows := [][]any{{&port, &ratio, "web"}}
+	// clear the port to a nil pointer, change the ratio, and discard the edit of the name with ESC
+	keys := [][]byte{
+		{'e'}, {0x7f, 0x7f}, {0x0a},
+		{0x1b, '[', 'C'}, {'e'}, {0x1b, '[', 'H'}, []byte("1"), {0x0a},
+		{0x1b, '[', 'C'}, {'e'}, []byte("server"), {0x1b}, {},
+		{0x0a},
+	}
+	edited, _ := selectWithOutput(t, keys, func() ([][]any, error) {
+		return select5.EditTable(rows)
+	})
+	if p, ok := edited[0][0].(*int); !ok || p != nil {
+		t.Errorf("Expected a nil *int, got %#v", edited[0][0])
+	}
+	if p, ok := edited[0][1].(*float64); !ok || *p != 10.5 {
+		t.Errorf("Expected a new *float64 of 10.5, got %#v", edited[0][1])
+	}
+	if edited[0][2] != "web" {
+		t.Errorf("Expected the name to be kept, got %v", edited[0][2])
+	}
+	if port != 80 || ratio != 0.5 {
+		t.Errorf("Expected the pointed values not to be modified, got %d and %g", port, ratio)
+	}
+}
+
+func TestEditTable_EmptyList(t *testing.T) {
+	if _, err := select5.EditTable(nil); !errors.Is(err, select5.ErrEmptyList) {
+		t.Fatalf("expected ErrEmptyList, got %v", err)
+	}
+}
+
+func TestEditTable_Types(t *testing.T) {
+	type name string
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := [][]any{{name("a"), uint8(1), float32(1.5), time.Second, when, nil}}
+	// put "2" before each value, which is invalid for the time
+	next := []byte("e\x1b[H2\n\x1b[C")
+	keys := [][]byte{next, next, next, next, []byte("e\x1b[H2\n"), {0x1b}, {}, []byte("\x1b[Ce2\n"), {0x0a}}
+	edited, out := selectWithOutput(t, keys, func() ([][]any, error) {
+		return select5.EditTable(rows)
+	})
+	expected := []any{name("2a"), uint8(21), float32(21.5), 21 * time.Second, when, "2"}
+	for j, v := range expected {
+		if edited[0][j] != v {
+			t.Errorf("Expected %#v in column %d, got %#v", v, j, edited[0][j])
+		}
+	}
+	if !strings.Contains(out, "invalid time.Time") {
+		t.Errorf("expected the error of the time in the output %q", out)
+	}
+}
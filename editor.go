@@ -34,37 +34,41 @@ func NewEditor() *Editor {
 	}
 }
 
-// Edit starts the editing session and returns the edited text when complete (with Ctrl-D)
-func (e *Editor) Edit() string {
+// Edit starts the editing session and returns the edited text when complete (with Ctrl-D).
+// If the session is interrupted with Ctrl+C or a signal, it returns the text so far and ErrInterrupted.
+func (e *Editor) Edit() (string, error) {
 	fmt.Fprint(e.Out, HideCursor)
 	fmt.Fprint(e.Out, ClearScreen)
 	fmt.Fprint(e.Out, ResetCursor)
 	fmt.Fprint(e.Out, ShowCursor)
 
-	keyCh, sigCh := CaptureKeyboardEvents()
 	var oldState *term.State
 	isTerm := term.IsTerminal(int(os.Stdin.Fd()))
 	if isTerm {
 		var err error
 		oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
 		if err != nil {
-			close(keyCh)
-			return ""
+			return "", fmt.Errorf("%w: %v", ErrNotTerminal, err)
 		}
 
 		defer term.Restore(int(os.Stdin.Fd()), oldState)
 		defer term.Restore(int(os.Stdout.Fd()), oldState)
 	}
+	// the key capture is stopped before the terminal is restored
+	done := make(chan struct{})
+	defer close(done)
+	keyCh, sigCh := captureKeyboardEvents(os.Stdin, done, false)
 	for {
 		select {
 		case sig := <-sigCh:
 			switch sig {
 			case syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT:
-				term.Restore(int(os.Stdin.Fd()), oldState)
-				term.Restore(int(os.Stdout.Fd()), oldState)
-				os.Exit(0)
+				return strings.Join(e.Line, "\n"), fmt.Errorf("%w by %s", ErrInterrupted, sig)
+			}
+		case key, ok := <-keyCh:
+			if !ok {
+				return strings.Join(e.Line, "\n"), fmt.Errorf("%w: keyboard event channel closed", ErrNotTerminal)
 			}
-		case key := <-keyCh:
 
 			switch key.Special {
 			case 0:
@@ -85,7 +89,7 @@ func (e *Editor) Edit() string {
 					case CtrlD:
 						//Ctrl-D -> end without clear screen
 						fmt.Fprint(e.Out, ResetCursor)
-						return strings.Join(e.Line, "\n")
+						return strings.Join(e.Line, "\n"), nil
 					case CtrlE:
 						e.Cursor.X = e.GetLineMaxX()
 					case CtrlP:
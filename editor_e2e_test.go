@@ -53,7 +53,7 @@ Akubishinagara`
 
 			var got string
 			go func() {
-				got = ed.Edit()
+				got, _ = ed.Edit()
 			}()
 			time.Sleep(100 * time.Millisecond)
 			w.Write([]byte(tt.fixtures))
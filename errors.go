@@ -0,0 +1,16 @@
+package select5
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the selection functions and the editor.
+// They are wrapped with the context, so check them with errors.Is.
+var (
+	ErrCanceled        = errors.New("selection canceled")           // the user quits the selection with q or ESC
+	ErrInterrupted     = fmt.Errorf("%w: interrupted", ErrCanceled) // Ctrl+C or a signal, which is also ErrCanceled
+	ErrEmptyList       = errors.New("zero length list provided")    // no item to select
+	ErrNotTerminal     = errors.New("terminal is not available")    // the terminal cannot be put in raw mode, or the input is closed
+	ErrUnsupportedType = errors.New("type not supported")           // the data cannot be shown or selected
+)
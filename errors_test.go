@@ -0,0 +1,123 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSelect_Canceled(t *testing.T) {
+	tests := []struct {
+		name        string
+		selectFunc  func() error
+		key         []byte
+		interrupted bool
+	}{
+		{"SelectString with ESC", func() error {
+			_, err := select5.SelectString([]string{"a", "b"})
+			return err
+		}, []byte{0x1b}, false},
+		{"SelectString with Ctrl+C", func() error {
+			_, err := select5.SelectString([]string{"a", "b"})
+			return err
+		}, []byte{select5.CtrlC}, true},
+		{"SelectTableRow with q", func() error {
+			_, err := select5.SelectTableRow([][]any{{"a", 1}, {"b", 2}})
+			return err
+		}, []byte{'q'}, false},
+		{"SelectStrings with ESC", func() error {
+			_, err := select5.SelectStrings([]string{"a", "b"})
+			return err
+		}, []byte{0x1b}, false},
+		{"Editor with Ctrl+C", func() error {
+			_, err := select5.NewEditor().Edit()
+			return err
+		}, []byte{select5.CtrlC}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := selectWithError(t, [][]byte{tt.key}, func() (any, error) {
+				return nil, tt.selectFunc()
+			})
+			if !errors.Is(err, select5.ErrCanceled) {
+				t.Fatalf("expected ErrCanceled, got %v", err)
+			}
+			if errors.Is(err, select5.ErrInterrupted) != tt.interrupted {
+				t.Fatalf("expected ErrInterrupted to be %v, got %v", tt.interrupted, err)
+			}
+		})
+	}
+}
+
+func TestSelect_Errors(t *testing.T) {
+	if _, err := select5.SelectString(nil); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("SelectString: expected ErrEmptyList, got %v", err)
+	}
+	if _, err := select5.SelectTableRows([][]any{}); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("SelectTableRows: expected ErrEmptyList, got %v", err)
+	}
+	if _, _, err := select5.SelectOf([]int{}, func(i int) string { return "" }); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("SelectOf: expected ErrEmptyList, got %v", err)
+	}
+	if _, err := (&select5.Selector{Data: 42}).Select(); !errors.Is(err, select5.ErrUnsupportedType) {
+		t.Errorf("Select: expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := select5.GetV(struct{}{}); !errors.Is(err, select5.ErrUnsupportedType) {
+		t.Errorf("GetV: expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestSelect_RenderError(t *testing.T) {
+	// a cell which cannot be shown ends the selection with the error, instead of an empty screen
+	rows := [][]any{{"a", []int{1}}, {"b", nil}}
+	if _, err := select5.SelectTableRow(rows); !errors.Is(err, select5.ErrUnsupportedType) {
+		t.Errorf("SelectTableRow: expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestSelect_InputClosed(t *testing.T) {
+	tests := []struct {
+		name       string
+		selectFunc func() error
+	}{
+		{"SelectString", func() error {
+			_, err := select5.SelectString([]string{"a", "b"})
+			return err
+		}},
+		{"Editor", func() error {
+			_, err := select5.NewEditor().Edit()
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			// EOF of stdin
+			w.Close()
+
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+
+			errCh := make(chan error)
+			go func() {
+				errCh <- tt.selectFunc()
+			}()
+			select {
+			case err := <-errCh:
+				if !errors.Is(err, select5.ErrNotTerminal) {
+					t.Fatalf("expected ErrNotTerminal, got %v", err)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for the error")
+			}
+		})
+	}
+}
@@ -2,23 +2,32 @@ package select5
 
 import (
 	"fmt"
+	"golang.org/x/sys/unix"
 	"golang.org/x/term"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 	"unicode/utf8"
 )
 
+// escTimeout is the pause after an ESC byte which tells a lone ESC key from an escape sequence
+const escTimeout = 50 * time.Millisecond
+
+// maxMouseSequence is the maximum length of an SGR mouse sequence
+const maxMouseSequence = 32
+
 // KeyEvent represents a keyboard input event with information about special keys and modifiers.
 type KeyEvent struct {
-	Key         rune   // The character pressed
-	Code        int    // Numeric key code
-	Ctrl        bool   // Whether Ctrl was pressed
-	Alt         bool   // Whether Alt was pressed
-	Shift       bool   // Whether Shift was pressed
-	Special     int    // Special key name (UP, DOWN, ENTER, etc.)
-	IsRuneStart bool   // Whether the character is UTF-8 multibyte character or not
-	Runes       []byte // Raw key bytes
+	Key         rune        // The character pressed
+	Code        int         // Numeric key code
+	Ctrl        bool        // Whether Ctrl was pressed
+	Alt         bool        // Whether Alt was pressed
+	Shift       bool        // Whether Shift was pressed
+	Special     int         // Special key name (UP, DOWN, ENTER, etc.)
+	IsRuneStart bool        // Whether the character is UTF-8 multibyte character or not
+	Runes       []byte      // Raw key bytes
+	Mouse       *MouseEvent // Mouse event if Special is MOUSE
 }
 
 // Utf8Char returns byte representation for the UTF-8 character.
@@ -39,6 +48,14 @@ func (e KeyEvent) Utf8Char() ([]byte, error) {
 	}
 }
 
+// IsPrintable returns true if the event is a printable character which can be put in a text input.
+func (e KeyEvent) IsPrintable() bool {
+	if e.Special != 0 || e.Ctrl || e.Alt {
+		return false
+	}
+	return e.IsRuneStart || (len(e.Runes) > 0 && e.Key >= 0x20 && e.Key < DEL)
+}
+
 // Size presents the size in octet order of the UTF-8 character.
 func (e KeyEvent) Size() (size int) {
 	for i, b := range e.Runes {
@@ -53,166 +70,244 @@ func (e KeyEvent) Size() (size int) {
 // CaptureKeyboardEvents starts capturing keyboard events in a background goroutine.
 // Returns a channel that delivers KeyEvent structs.
 // The channel should be properly handled and the goroutine will exit when the channel is closed.
+// The terminal is put in raw mode by the goroutine, and restored when it exits.
 func CaptureKeyboardEvents() (chan KeyEvent, chan os.Signal) {
+	return captureKeyboardEvents(os.Stdin, nil, true)
+}
+
+// captureKeyboardEvents works like CaptureKeyboardEvents for the input file, and the goroutine also exits when done is closed.
+// Then the key channel is closed, and the signals are no longer delivered.
+// The goroutine puts the terminal in raw mode only if raw is true. Otherwise the caller owns the raw mode,
+// since a restore by the goroutine on exit races with the restore by the caller.
+func captureKeyboardEvents(in *os.File, done <-chan struct{}, raw bool) (chan KeyEvent, chan os.Signal) {
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGSTOP, syscall.SIGCONT, syscall.SIGQUIT)
 	keyChannel := make(chan KeyEvent, 10)
+	out := os.Stdout
 
 	go func() {
-		// Check if os.Stdin is a terminal and set up raw mode if it is
+		// Check if the input is a terminal and set up raw mode if it is
 		var oldState *term.State
-		isTerm := term.IsTerminal(int(os.Stdin.Fd()))
-		if isTerm {
+		isTerm := term.IsTerminal(int(in.Fd()))
+		if raw && isTerm {
 			var err error
-			oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
+			oldState, err = term.MakeRaw(int(in.Fd()))
 			if err != nil {
 				close(keyChannel)
 				return
 			}
-			defer term.Restore(int(os.Stdin.Fd()), oldState)
-			defer term.Restore(int(os.Stdout.Fd()), oldState)
+			defer term.Restore(int(in.Fd()), oldState)
+			defer term.Restore(int(out.Fd()), oldState)
 		}
 
+		// read bytes in another goroutine, so that a lone ESC can be detected with a timeout
+		stop := make(chan struct{})
+		defer close(stop)
+		byteChannel := readBytes(in, stop)
+
 		buffer := make([]byte, 0, 8)
-		oneByte := make([]byte, 1)
 		for {
-			n, err := os.Stdin.Read(oneByte)
-			if err != nil {
+			var b byte
+			var ok bool
+			if len(buffer) == 1 && buffer[0] == ESC {
+				select {
+				case b, ok = <-byteChannel:
+				case <-done:
+					signal.Stop(sigChan)
+					close(keyChannel)
+					return
+				case <-time.After(escTimeout):
+					keyChannel <- KeyEvent{
+						Key:     ESC,
+						Code:    ESC,
+						Special: ESC,
+					}
+					buffer = buffer[:0]
+					continue
+				}
+			} else {
+				select {
+				case b, ok = <-byteChannel:
+				case <-done:
+					signal.Stop(sigChan)
+					close(keyChannel)
+					return
+				}
+			}
+			if !ok {
 				close(keyChannel)
 				return
 			}
 
-			if n > 0 {
-				buffer = append(buffer, oneByte[0])
-				//key := KeyEvent{
-				//	Key:   rune(oneByte[0]),
-				//	Code:  int(oneByte[0]),
-				//	Runes: oneByte,
-				//}
-				if buffer[0] == ESC {
-					var escapedKey int
-					// Check for special keys or modifiers
-					// Check for arrow keys and other special keys
-					if len(buffer) >= 3 && buffer[1] == '[' {
-						complete := false
-						if len(buffer) == 3 {
-							switch buffer[2] {
-							case 'A', 'B', 'C', 'D', 'F', 'H':
-								complete = true
-							}
+			buffer = append(buffer, b)
+			//key := KeyEvent{
+			//	Key:   rune(oneByte[0]),
+			//	Code:  int(oneByte[0]),
+			//	Runes: oneByte,
+			//}
+			if buffer[0] == ESC {
+				var escapedKey int
+				// ESC followed by another ESC or a character is a lone ESC or an Alt modified key
+				if len(buffer) == 2 && buffer[1] != '[' && buffer[1] != 'O' {
+					if buffer[1] == ESC {
+						keyChannel <- KeyEvent{
+							Key:     ESC,
+							Code:    ESC,
+							Special: ESC,
 						}
-						if len(buffer) == 4 && buffer[3] == '~' {
-							complete = true
-						}
-						if complete {
-							switch buffer[2] {
-							case 'A':
-								escapedKey = UP
-							case 'B':
-								escapedKey = DOWN
-							case 'C':
-								escapedKey = RIGHT
-							case 'D':
-								escapedKey = LEFT
-							case '5':
-								escapedKey = PAGEUP
-							case '6':
-								escapedKey = PAGEDOWN
-							case 'F':
-								escapedKey = END
-							case 'H':
-								escapedKey = HOME
-							}
-							keyCode := int(buffer[0]<<4) | int(buffer[1])<<2 | int(buffer[2])
-							keyChannel <- KeyEvent{
-								Key:     rune(buffer[1]),
-								Code:    keyCode,
-								Special: escapedKey,
-							}
-							//clear
-							buffer = buffer[:0]
-							continue
-						}
-
-					}
-					if len(buffer) < 4 {
+						buffer = buffer[:1]
 						continue
 					}
-					keyCode := int(buffer[0]<<4) | int(buffer[1])<<2 | int(buffer[2])
 					keyChannel <- KeyEvent{
-						Key:     rune(buffer[1]),
-						Code:    keyCode,
-						Special: escapedKey,
+						Key:   rune(buffer[1]),
+						Code:  int(buffer[1]),
+						Alt:   true,
+						Runes: []byte{buffer[1], 0, 0, 0, 0, 0},
 					}
-					//clear
 					buffer = buffer[:0]
 					continue
 				}
-				if buffer[0] == ENTER || buffer[0] == 0x0d {
-					keyChannel <- KeyEvent{
-						Key:     rune(ENTER),
-						Code:    ENTER,
-						Special: ENTER,
-						Runes:   buffer[:1],
+				// SGR mouse sequence ends with 'M' (press) or 'm' (release)
+				if len(buffer) >= 3 && buffer[1] == '[' && buffer[2] == '<' {
+					if b == 'M' || b == 'm' {
+						if key, ok := parseMouse(buffer); ok {
+							keyChannel <- key
+						}
+						buffer = buffer[:0]
+					} else if len(buffer) > maxMouseSequence {
+						// malformed sequence
+						buffer = buffer[:0]
 					}
-					buffer = buffer[:0] //clear
-					continue
-				} else if buffer[0]&0x80 != 0x80 {
-					//ascii character handling
-					sendASCII(buffer[0], keyChannel, sigChan)
-					buffer = buffer[:0] //clear
 					continue
 				}
-				if utf8.FullRune(buffer) {
-					r, size := utf8.DecodeRune(buffer)
-					if r != utf8.RuneError {
-						key := KeyEvent{
-							Key:         r,
-							Code:        int(buffer[0]),
-							IsRuneStart: true,
-							Runes:       make([]byte, 6), // TODO: check maxSize = 6?
+				// Check for special keys or modifiers
+				// Check for arrow keys and other special keys
+				if len(buffer) >= 3 && (buffer[1] == '[' || buffer[1] == 'O') {
+					complete := false
+					if len(buffer) == 3 {
+						switch buffer[2] {
+						case 'A', 'B', 'C', 'D', 'F', 'H':
+							complete = true
+						}
+					}
+					if len(buffer) == 4 && buffer[3] == '~' {
+						complete = true
+					}
+					if complete {
+						switch buffer[2] {
+						case 'A':
+							escapedKey = UP
+						case 'B':
+							escapedKey = DOWN
+						case 'C':
+							escapedKey = RIGHT
+						case 'D':
+							escapedKey = LEFT
+						case '5':
+							escapedKey = PAGEUP
+						case '6':
+							escapedKey = PAGEDOWN
+						case 'F', '4', '8':
+							escapedKey = END
+						case 'H', '1', '7':
+							escapedKey = HOME
+						}
+						keyCode := int(buffer[0]<<4) | int(buffer[1])<<2 | int(buffer[2])
+						keyChannel <- KeyEvent{
+							Key:     rune(buffer[1]),
+							Code:    keyCode,
+							Special: escapedKey,
 						}
-						copy(key.Runes, buffer[:size])
-						keyChannel <- key
-						buffer = buffer[size:] // remove processed bytes
+						//clear
+						buffer = buffer[:0]
+						continue
+					}
+
+				}
+				if len(buffer) < 4 {
+					continue
+				}
+				keyCode := int(buffer[0]<<4) | int(buffer[1])<<2 | int(buffer[2])
+				keyChannel <- KeyEvent{
+					Key:     rune(buffer[1]),
+					Code:    keyCode,
+					Special: escapedKey,
+				}
+				//clear
+				buffer = buffer[:0]
+				continue
+			}
+			if buffer[0] == ENTER || buffer[0] == 0x0d {
+				keyChannel <- KeyEvent{
+					Key:     rune(ENTER),
+					Code:    ENTER,
+					Special: ENTER,
+					Runes:   buffer[:1],
+				}
+				buffer = buffer[:0] //clear
+				continue
+			} else if buffer[0]&0x80 != 0x80 {
+				//ascii character handling
+				sendASCII(buffer[0], keyChannel, sigChan)
+				if buffer[0] == CtrlC {
+					// the key channel is closed by Ctrl+C
+					return
+				}
+				buffer = buffer[:0] //clear
+				continue
+			}
+			if utf8.FullRune(buffer) {
+				r, size := utf8.DecodeRune(buffer)
+				if r != utf8.RuneError {
+					key := KeyEvent{
+						Key:         r,
+						Code:        int(buffer[0]),
+						IsRuneStart: true,
+						Runes:       make([]byte, 6), // TODO: check maxSize = 6?
+					}
+					copy(key.Runes, buffer[:size])
+					keyChannel <- key
+					buffer = buffer[size:] // remove processed bytes
 
-						// if we had one or more character,
-						if len(buffer) > 0 {
-							// remaining bytes back for processing
-							remainder := make([]byte, len(buffer))
-							copy(remainder, buffer)
-							buffer = buffer[:0] //clear
+					// if we had one or more character,
+					if len(buffer) > 0 {
+						// remaining bytes back for processing
+						remainder := make([]byte, len(buffer))
+						copy(remainder, buffer)
+						buffer = buffer[:0] //clear
 
-							// process each remaining byte
-							for _, b := range remainder {
-								buffer = append(buffer, b)
-								// Try to process if it's a complete sequence
-								if utf8.FullRune(buffer) || buffer[0] < 128 {
-									break //reset
-								}
+						// process each remaining byte
+						for _, b := range remainder {
+							buffer = append(buffer, b)
+							// Try to process if it's a complete sequence
+							if utf8.FullRune(buffer) || buffer[0] < 128 {
+								break //reset
 							}
-							continue
 						}
-
-					} else if size > 0 {
-						// invalid UTF-8 sequence, but we consumed some bytes
-						buffer = buffer[size:] // skip
 						continue
 					}
-				}
-				if len(buffer) < 4 {
+
+				} else if size > 0 {
+					// invalid UTF-8 sequence, but we consumed some bytes
+					buffer = buffer[size:] // skip
 					continue
 				}
+			}
+			if len(buffer) < 4 {
+				continue
+			}
 
-				// If buffer is getting too large but no valid character,
-				// emit what we have as individual bytes (likely garbage)
-				if len(buffer) > 6 {
-					for _, b := range buffer {
-						sendASCII(b, keyChannel, sigChan)
+			// If buffer is getting too large but no valid character,
+			// emit what we have as individual bytes (likely garbage)
+			if len(buffer) > 6 {
+				for _, b := range buffer {
+					sendASCII(b, keyChannel, sigChan)
+					if b == CtrlC {
+						return
 					}
-					buffer = buffer[:0] // Clear buffer
 				}
+				buffer = buffer[:0] // Clear buffer
 			}
 
 		}
@@ -220,6 +315,74 @@ func CaptureKeyboardEvents() (chan KeyEvent, chan os.Signal) {
 	return keyChannel, sigChan
 }
 
+// readBytes returns the channel of the bytes read from the input file.
+// The reader goroutine waits on the file and a wake-up pipe before each read, so that it exits when stop is closed
+// without taking a byte for the next reader of the file. The channel is closed when the goroutine exits.
+func readBytes(in *os.File, stop <-chan struct{}) <-chan byte {
+	ch := make(chan byte)
+	conn, err := in.SyscallConn()
+	if err != nil {
+		close(ch)
+		return ch
+	}
+	wakeR, wakeW, err := os.Pipe()
+	if err != nil {
+		close(ch)
+		return ch
+	}
+	go func() {
+		<-stop
+		wakeW.Close()
+	}()
+	go func() {
+		defer close(ch)
+		defer wakeR.Close()
+		wake := int(wakeR.Fd())
+		oneByte := make([]byte, 1)
+		for {
+			var n int
+			var end bool
+			// the file descriptor is not closed (and reused) by a Close of the file during the wait and the read
+			err := conn.Read(func(fd uintptr) bool {
+				n, end = pollRead(int(fd), wake, oneByte)
+				return true
+			})
+			if err != nil || end {
+				return
+			}
+			if n == 0 {
+				continue
+			}
+			select {
+			case ch <- oneByte[0]:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// pollRead waits until the file descriptor or the wake-up pipe is ready, and reads the bytes if the file is ready.
+// It returns the number of bytes read, and true if the pipe is woken up, or the file is closed or fails.
+func pollRead(fd, wake int, b []byte) (int, bool) {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}, {Fd: int32(wake), Events: unix.POLLIN}}
+	if _, err := unix.Poll(fds, -1); err != nil {
+		return 0, err != unix.EINTR
+	}
+	if fds[1].Revents != 0 || fds[0].Revents&unix.POLLNVAL != 0 {
+		return 0, true
+	}
+	if fds[0].Revents == 0 {
+		return 0, false
+	}
+	n, err := unix.Read(fd, b)
+	if err == unix.EAGAIN || err == unix.EINTR {
+		return 0, false
+	}
+	return n, err != nil || n == 0
+}
+
 // send ASCII and control characters
 func sendASCII(b byte, keyChannel chan KeyEvent, sigChan chan os.Signal) {
 	key := KeyEvent{
@@ -147,6 +147,68 @@ func TestCaptureKeyboardEventsSpecialChar(t *testing.T) {
 	}
 }
 
+func TestCaptureKeyboardEventsEscAndAlt(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	keyChannel, _ := select5.CaptureKeyboardEvents()
+
+	// a lone ESC is emitted after a pause
+	w.Write([]byte{select5.ESC})
+	select {
+	case k := <-keyChannel:
+		if k.Special != select5.ESC {
+			t.Fatalf("invalid special key: %x, expected ESC", k.Special)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for key event")
+	}
+
+	// ESC followed by a character is an Alt modified key
+	w.Write([]byte{select5.ESC, 'x'})
+	select {
+	case k := <-keyChannel:
+		if !k.Alt || k.Key != 'x' {
+			t.Fatalf("invalid key: %c (alt: %v), expected Alt+x", k.Key, k.Alt)
+		}
+		if k.IsPrintable() {
+			t.Fatal("Alt modified key should not be printable")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for key event")
+	}
+}
+
+func TestKeyEvent_IsPrintable(t *testing.T) {
+	tt := []struct {
+		name  string
+		event select5.KeyEvent
+		want  bool
+	}{
+		{"alphabet", select5.KeyEvent{Key: 'a', Code: 'a', Runes: []byte{'a', 0, 0, 0, 0, 0}}, true},
+		{"space", select5.KeyEvent{Key: ' ', Code: ' ', Runes: []byte{' ', 0, 0, 0, 0, 0}}, true},
+		{"UTF-8", select5.KeyEvent{Key: 'あ', Code: 0xe3, IsRuneStart: true, Runes: []byte{0xe3, 0x81, 0x82, 0, 0, 0}}, true},
+		{"control-a", select5.KeyEvent{Key: 0x01, Code: 0x01, Ctrl: true, Runes: []byte{0x01, 0, 0, 0, 0, 0}}, false},
+		{"delete", select5.KeyEvent{Key: select5.DEL, Code: select5.DEL, Special: select5.DEL, Runes: []byte{select5.DEL, 0, 0, 0, 0, 0}}, false},
+		{"arrow up", select5.KeyEvent{Key: '[', Code: 0x1b5b41, Special: select5.UP}, false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.event.IsPrintable(); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestCaptureKeyboardEventsNonInterruptCtrl(t *testing.T) {
 	testTarget := []byte{select5.CtrlA, select5.CtrlB, select5.CtrlD, select5.CtrlE, select5.CtrlN, select5.CtrlP, select5.CtrlX, select5.CtrlY}
 	for i, key := range testTarget {
@@ -9,7 +9,10 @@ import (
 
 func main() {
 	ed := select5.NewEditor()
-	res := ed.Edit()
+	res, err := ed.Edit()
+	if err != nil {
+		panic(err)
+	}
 	t, err := os.CreateTemp(".", "result-*.txt")
 	defer t.Close()
 	if err != nil {
@@ -0,0 +1,274 @@
+package select5
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Operators of the filter expression, longest first
+var filterOperators = []string{"!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+// filterCondition is a condition of the filter expression for a table (internal use)
+type filterCondition struct {
+	column int    // column index, or -1 for any column
+	op     string // one of filterOperators
+	value  string // value as typed
+	kind   int    // sortNumber, sortBool or sortString to compare the cells
+	f      float64
+	i      int64
+	isInt  bool
+	b      bool
+}
+
+// splitFilterTerms splits the expression by spaces. Double quoted spaces are kept in the term.
+func splitFilterTerms(expr string) ([]string, error) {
+	var (
+		terms  []string
+		term   strings.Builder
+		quoted bool
+		inTerm bool
+	)
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inTerm = true
+		case unicode.IsSpace(r) && !quoted:
+			if inTerm {
+				terms = append(terms, term.String())
+				term.Reset()
+				inTerm = false
+			}
+		default:
+			term.WriteRune(r)
+			inTerm = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inTerm {
+		terms = append(terms, term.String())
+	}
+	return terms, nil
+}
+
+// parseFilter parses the filter expression for the table.
+// The expression is a list of conditions separated by spaces, which must be all satisfied.
+// A condition is COLUMN OP VALUE, where COLUMN is a header name or a column number starting from 1,
+// and OP is one of =, !=, >, >=, <, <=, ~ (contains) and !~ (does not contain).
+// A condition without an operator matches the rows which contain the text in any cell.
+func (t *table) parseFilter(expr string) ([]filterCondition, error) {
+	terms, err := splitFilterTerms(expr)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []filterCondition
+	for _, term := range terms {
+		pos := strings.IndexAny(term, "=!<>~")
+		if pos < 0 {
+			conditions = append(conditions, filterCondition{column: -1, op: "~", value: term, kind: sortString})
+			continue
+		}
+		c := filterCondition{}
+		for _, op := range filterOperators {
+			if strings.HasPrefix(term[pos:], op) {
+				c.op = op
+				break
+			}
+		}
+		if c.op == "" {
+			return nil, fmt.Errorf("invalid operator in %q", term)
+		}
+		name := term[:pos]
+		c.value = term[pos+len(c.op):]
+		if name == "" {
+			return nil, fmt.Errorf("missing column in %q", term)
+		}
+		if c.value == "" {
+			return nil, fmt.Errorf("missing value in %q", term)
+		}
+		if c.column, err = t.findColumn(name); err != nil {
+			return nil, err
+		}
+		if err := c.parseValue(t.columnKind(c.column), t.columnName(c.column)); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// findColumn returns the index of the column with the header name (case-insensitive) or the column number
+func (t *table) findColumn(name string) (int, error) {
+	for i, h := range t.header {
+		if strings.EqualFold(h, name) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= 1 && n <= t.columns() {
+		return n - 1, nil
+	}
+	return -1, fmt.Errorf("unknown column %q", name)
+}
+
+// columnKind returns the kind of the values in the column, detected with CheckPrimitive.
+// A column of mixed or unsupported types is compared as strings.
+func (t *table) columnKind(column int) int {
+	var detected byte
+	for _, row := range t.rows {
+		if column < len(row) && row[column] != nil {
+			detected |= CheckPrimitive(row[column]) &^ IsPointer
+		}
+	}
+	switch {
+	case detected == 0:
+		return sortString
+	case detected&^(IsInt|IsInt64|IsFloat32|IsFloat64) == 0:
+		return sortNumber
+	case detected == IsBool:
+		return sortBool
+	default:
+		return sortString
+	}
+}
+
+// parseValue parses the value of the condition for the kind of the column
+func (c *filterCondition) parseValue(kind int, name string) error {
+	c.kind = kind
+	if c.op == "~" || c.op == "!~" {
+		c.kind = sortString
+		return nil
+	}
+	switch kind {
+	case sortNumber:
+		if i, err := strconv.ParseInt(c.value, 10, 64); err == nil {
+			c.i, c.f, c.isInt = i, float64(i), true
+			return nil
+		}
+		f, err := strconv.ParseFloat(c.value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q for the column %s", c.value, name)
+		}
+		c.f = f
+	case sortBool:
+		b, err := strconv.ParseBool(c.value)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q for the column %s", c.value, name)
+		}
+		if c.op != "=" && c.op != "!=" {
+			return fmt.Errorf("operator %s is not supported for the bool column %s", c.op, name)
+		}
+		c.b = b
+	}
+	return nil
+}
+
+// matchCell reports whether the cell value satisfies the condition
+func (c filterCondition) matchCell(v any) bool {
+	k := newSortKey(v)
+	negative := c.op == "!=" || c.op == "!~"
+	if k.kind == sortNil {
+		return negative
+	}
+	var order int
+	switch c.kind {
+	case sortNumber:
+		if k.kind != sortNumber {
+			return negative
+		}
+		if k.isInt && c.isInt {
+			order = cmp.Compare(k.i, c.i)
+		} else {
+			order = cmp.Compare(k.f, c.f)
+		}
+	case sortBool:
+		if k.kind != sortBool {
+			return negative
+		}
+		if k.b != c.b {
+			order = 1
+		}
+	default:
+		s, _ := cellString(v)
+		switch c.op {
+		case "~":
+			return strings.Contains(strings.ToLower(s), strings.ToLower(c.value))
+		case "!~":
+			return !strings.Contains(strings.ToLower(s), strings.ToLower(c.value))
+		case "=", "!=":
+			if !strings.EqualFold(s, c.value) {
+				order = 1
+			}
+		default:
+			order = collate(s, c.value)
+		}
+	}
+	switch c.op {
+	case "=":
+		return order == 0
+	case "!=":
+		return order != 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	}
+	return false
+}
+
+// matchRow reports whether the row satisfies the condition, and returns the matched columns
+func (c filterCondition) matchRow(row []any) (bool, []int) {
+	if c.column >= 0 {
+		var v any
+		if c.column < len(row) {
+			v = row[c.column]
+		}
+		if c.matchCell(v) {
+			return true, []int{c.column}
+		}
+		return false, nil
+	}
+	var columns []int
+	for j, v := range row {
+		if c.matchCell(v) {
+			columns = append(columns, j)
+		}
+	}
+	return len(columns) > 0, columns
+}
+
+// search filters the rows of the table with the filter expression, and keeps the matched cells for the rendering.
+// If the expression is invalid, all the rows are shown with the error.
+func (t *table) search(expr string) ([]int, error) {
+	conditions, err := t.parseFilter(expr)
+	t.matched = map[int]map[int]bool{}
+	view := make([]int, 0, len(t.rows))
+	for i, row := range t.rows {
+		ok := true
+		for _, c := range conditions {
+			matched, columns := c.matchRow(row)
+			if !matched {
+				ok = false
+				break
+			}
+			for _, j := range columns {
+				if t.matched[i] == nil {
+					t.matched[i] = map[int]bool{}
+				}
+				t.matched[i][j] = true
+			}
+		}
+		if ok {
+			view = append(view, i)
+		}
+	}
+	return view, err
+}
@@ -0,0 +1,82 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSelectTableRow_Filter(t *testing.T) {
+	header := []string{"Code", "Name", "Price", "Active"}
+	rows := [][]any{
+		{"a", "Apple Inc.", 178.72, true},
+		{"b", "Broadcom", 376.04, false},
+		{"c", "Cisco", 125.30, true},
+		{"d", "Dell", 95, true},
+	}
+	tests := []struct {
+		name string
+		expr string
+		down int
+		want int
+	}{
+		{"float column", "price>150", 1, 1},
+		{"int and float in a column", "price<=95", 0, 3},
+		{"bool column", "active=false", 0, 1},
+		{"contains", "name~cis", 0, 2},
+		{"column number", "2~CIS", 0, 2},
+		{"any column", "broad", 0, 1},
+		{"all conditions", "price<200 active=true", 1, 2},
+		{"not equal", "code!=a", 0, 1},
+		{"quoted value", `name~"inc."`, 0, 0},
+		{"invalid number shows all rows", "price>abc", 1, 1},
+		{"unknown column shows all rows", "volume>3", 2, 2},
+		{"unsupported operator for bool", "active>true", 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+
+			resultCh := make(chan []any)
+			go func() {
+				result, err := select5.SelectTableRowWithHeader(header, rows)
+				if err != nil {
+					panic(err)
+				}
+				resultCh <- result
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte{'/'})
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(tt.expr))
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte{0x0a}) // ENTER key ends the filter
+			time.Sleep(20 * time.Millisecond)
+			for i := 0; i < tt.down; i++ {
+				w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+				time.Sleep(20 * time.Millisecond)
+			}
+			w.Write([]byte{0x0a}) // ENTER key
+
+			select {
+			case result := <-resultCh:
+				if !rowsEqual(result, rows[tt.want]) {
+					t.Fatalf("Expected row %v to be selected, got %v", rows[tt.want], result)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for selection")
+			}
+		})
+	}
+}
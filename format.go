@@ -0,0 +1,147 @@
+package select5
+
+import (
+	"encoding/json"
+	"github.com/olekukonko/tablewriter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Alignment is the horizontal alignment of the cells in a table column
+type Alignment int
+
+// Alignments of the table columns
+const (
+	AlignAuto   Alignment = iota // numbers to the right, and the others to the left (default)
+	AlignLeft                    // to the left
+	AlignRight                   // to the right
+	AlignCenter                  // to the center
+)
+
+// NoDecimals is the Precision of ColumnFormat to show the floats without the decimal point
+const NoDecimals = -1
+
+// ColumnFormat is the way to show the cells of a table column.
+// It changes only the text shown in the table, and the selected rows have the original values.
+type ColumnFormat struct {
+	Precision int                // digits after the decimal point of floats, NoDecimals for none, or 0 for the default (6 digits)
+	Thousands string             // separator of the thousands of numbers, like ","
+	Align     Alignment          // alignment of the cells
+	True      string             // glyph of true ("✓" if both True and False are empty)
+	False     string             // glyph of false
+	Format    func(v any) string // formats the cell value (which may be a pointer) instead of the other options, except Align
+}
+
+// columnFormat returns the format of the column in the options, or the zero format
+func (o SelectOptions) columnFormat(column int) ColumnFormat {
+	if column < len(o.ColumnFormats) {
+		return o.ColumnFormats[column]
+	}
+	return ColumnFormat{}
+}
+
+// format returns the text of a table cell with the format.
+// A nil pointer is shown as an empty cell, unless the Format function is set.
+func (f ColumnFormat) format(v any) (string, error) {
+	if f.Format != nil {
+		return f.Format(v), nil
+	}
+	r := reflect.ValueOf(v)
+	if r.Kind() == reflect.Pointer {
+		if r.IsNil() {
+			return "", nil
+		}
+		r = r.Elem()
+	}
+	if r.IsValid() && r.Type() == reflect.TypeFor[json.Number]() {
+		n := json.Number(r.String())
+		// formatted like the number it represents
+		if i, err := n.Int64(); err == nil {
+			r = reflect.ValueOf(i)
+		} else if x, err := n.Float64(); err == nil {
+			r = reflect.ValueOf(x)
+		}
+	} else if r.IsValid() && (hasTextMethod(reflect.TypeOf(v)) || hasTextMethod(r.Type())) {
+		// a value shown with its method (like time.Duration or a named int with String) is not formatted as a number
+		return cellString(v)
+	}
+	switch r.Kind() {
+	case reflect.Bool:
+		if f.True == "" && f.False == "" {
+			break
+		}
+		if r.Bool() {
+			return f.True, nil
+		}
+		return f.False, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f.Thousands != "" {
+			return groupThousands(strconv.FormatInt(r.Int(), 10), f.Thousands), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if f.Thousands != "" {
+			return groupThousands(strconv.FormatUint(r.Uint(), 10), f.Thousands), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f.Precision == 0 && f.Thousands == "" {
+			break
+		}
+		precision := f.Precision
+		switch {
+		case precision == 0:
+			precision = 6 // like %f
+		case precision < 0:
+			precision = 0
+		}
+		return groupThousands(strconv.FormatFloat(r.Float(), 'f', precision, r.Type().Bits()), f.Thousands), nil
+	}
+	return cellString(v)
+}
+
+// groupThousands puts the separator between the thousands of the integer part of the number
+func groupThousands(number string, sep string) string {
+	if sep == "" {
+		return number
+	}
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	integer, fraction := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		integer, fraction = number[:i], number[i:]
+	}
+	var b strings.Builder
+	for i, d := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + fraction
+}
+
+// alignments returns the tablewriter alignments of the columns.
+// The columns of numbers are aligned to the right with AlignAuto.
+func (t *table) alignments(s *session, columns int) []int {
+	res := make([]int, columns)
+	for j := range res {
+		align := s.opts.columnFormat(j).Align
+		if align == AlignAuto {
+			align = AlignLeft
+			if t.columnKind(j) == sortNumber {
+				align = AlignRight
+			}
+		}
+		switch align {
+		case AlignRight:
+			res[j] = tablewriter.ALIGN_RIGHT
+		case AlignCenter:
+			res[j] = tablewriter.ALIGN_CENTER
+		default:
+			res[j] = tablewriter.ALIGN_LEFT
+		}
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package select5_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/g1eng/select5"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectOptions_ColumnFormats(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	rows := [][]any{
+		{"alpha", 1234567, 1.5, true},
+		{"beta", -1000, 22.256, false},
+	}
+	opts := select5.SelectOptions{
+		Header: []string{"NAME", "COUNT", "RATIO", "VALID"},
+		ColumnFormats: []select5.ColumnFormat{
+			{Align: select5.AlignRight},
+			{Thousands: ","},
+			{Precision: 2},
+			{True: "yes", False: "no", Align: select5.AlignCenter},
+		},
+	}
+	keys := [][]byte{{0x1b, '[', 'B'}, {0x0a}}
+	row, out := selectWithOutput(t, keys, func() ([]any, error) {
+		return select5.SelectTableRowWith(rows, opts)
+	})
+	if !reflect.DeepEqual(row, rows[1]) {
+		t.Fatalf("Expected the original row %v, got %v", rows[1], row)
+	}
+	for _, s := range []string{
+		"  alpha ", "   beta ", // right-aligned by the option
+		" 1,234,567 ", "    -1,000 ", // numbers are right-aligned by default
+		" 1.50 ", " 22.26 ",
+		"  yes  ", // centered in the header width
+		"  no  ",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+	if strings.Contains(out, "1.500000") {
+		t.Errorf("expected the precision to be applied in the output %q", out)
+	}
+}
+
+func TestSelectOptions_ColumnFormatsUnsigned(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	size := uint64(9876543)
+	rows := [][]any{{uint64(1234567), &size, json.Number("7654321"), json.Number("1234.5")}}
+	format := select5.ColumnFormat{Thousands: ","}
+	opts := select5.SelectOptions{ColumnFormats: []select5.ColumnFormat{format, format, format, format}}
+	_, out := selectWithOutput(t, [][]byte{{0x0a}}, func() ([]any, error) {
+		return select5.SelectTableRowWith(rows, opts)
+	})
+	for _, s := range []string{" 1,234,567 ", " 9,876,543 ", " 7,654,321 ", " 1,234.500000 "} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+}
+
+func TestSelectOptions_ColumnFormatsText(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	high := level(3000)
+	rows := [][]any{{90 * time.Second, level(2000), &high, true}}
+	format := select5.ColumnFormat{Thousands: ",", Precision: 2}
+	opts := select5.SelectOptions{ColumnFormats: []select5.ColumnFormat{format, format, format, format}}
+	_, out := selectWithOutput(t, [][]byte{{0x0a}}, func() ([]any, error) {
+		return select5.SelectTableRowWith(rows, opts)
+	})
+	// the values shown with their methods are not grouped as numbers
+	for _, s := range []string{" 1m30s ", " level-2000 ", " level-3000 "} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+	if strings.Contains(out, "90,000,000,000") {
+		t.Errorf("expected the duration not to be grouped in the output %q", out)
+	}
+}
+
+func TestSelectOptions_ColumnFormatFunc(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	size := 2048
+	rows := [][]any{{"a.txt", &size}, {"b.txt", (*int)(nil)}}
+	opts := select5.SelectOptions{
+		ColumnFormats: []select5.ColumnFormat{{}, {
+			Format: func(v any) string {
+				if p := v.(*int); p != nil {
+					return fmt.Sprintf("%d KiB", *p/1024)
+				}
+				return "-"
+			},
+		}},
+	}
+	row, out := selectWithOutput(t, [][]byte{{0x0a}}, func() ([]any, error) {
+		return select5.SelectTableRowWith(rows, opts)
+	})
+	if row[1] != &size {
+		t.Fatalf("Expected the original pointer, got %v", row[1])
+	}
+	for _, s := range []string{"2 KiB", " - "} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package select5
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Scores for the fuzzy matcher
+const (
+	fuzzyScoreMatch       = 16 // score for each matched character
+	fuzzyBonusBoundary    = 8  // bonus for a match at the beginning of a word
+	fuzzyBonusConsecutive = 4  // bonus for a match right after the previous one
+	fuzzyPenaltyGap       = 1  // penalty for each skipped character inside the match
+)
+
+// FuzzyMatch reports whether all characters of the pattern appear in s in the same order.
+// It returns the score of the match (higher is better) and the rune positions of the matched characters in s.
+// The match is case-insensitive unless the pattern contains an upper case character.
+// An empty pattern matches any string with zero score.
+func FuzzyMatch(pattern string, s string) (score int, positions []int, ok bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	caseSensitive := false
+	for _, r := range p {
+		if unicode.IsUpper(r) {
+			caseSensitive = true
+			break
+		}
+	}
+	text := []rune(s)
+	equal := func(a, b rune) bool {
+		if caseSensitive {
+			return a == b
+		}
+		return unicode.ToLower(a) == unicode.ToLower(b)
+	}
+
+	// find the first end position of the match
+	pi, end := 0, -1
+	for i, r := range text {
+		if equal(r, p[pi]) {
+			pi++
+			if pi == len(p) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// then search backward for the shortest match which ends at the position
+	pi, start := len(p)-1, 0
+	for i := end; i >= 0; i-- {
+		if equal(text[i], p[pi]) {
+			pi--
+			if pi < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	pi = 0
+	prev := -1
+	for i := start; i <= end && pi < len(p); i++ {
+		if !equal(text[i], p[pi]) {
+			continue
+		}
+		score += fuzzyScoreMatch
+		if i == 0 || isWordBoundary(text[i-1], text[i]) {
+			score += fuzzyBonusBoundary
+		}
+		if prev >= 0 {
+			if prev == i-1 {
+				score += fuzzyBonusConsecutive
+			} else {
+				score -= fuzzyPenaltyGap * (i - prev - 1)
+			}
+		}
+		positions = append(positions, i)
+		prev = i
+		pi++
+	}
+	return score, positions, true
+}
+
+// isWordBoundary returns true if the character r starts a new word after the character prev
+func isWordBoundary(prev rune, r rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
+
+// fuzzyFilter returns the indices of the items which match the pattern, ordered by the score,
+// and the matched rune positions for each of them.
+// The original order is kept for an empty pattern and for the items with the same score.
+func fuzzyFilter(pattern string, items []string) ([]int, map[int][]int) {
+	var (
+		indices   []int
+		scores    = map[int]int{}
+		positions = map[int][]int{}
+	)
+	for i, item := range items {
+		score, pos, ok := FuzzyMatch(pattern, item)
+		if !ok {
+			continue
+		}
+		indices = append(indices, i)
+		scores[i] = score
+		if len(pos) > 0 {
+			positions[i] = pos
+		}
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return scores[indices[a]] > scores[indices[b]]
+	})
+	return indices, positions
+}
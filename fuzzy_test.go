@@ -0,0 +1,50 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		s             string
+		wantOk        bool
+		wantPositions []int
+	}{
+		{"empty pattern", "", "anything", true, nil},
+		{"prefix", "dev", "dev-cluster", true, []int{0, 1, 2}},
+		{"subsequence", "pcl", "prod-cluster", true, []int{0, 5, 6}},
+		{"case insensitive", "PROD", "prod-cluster", false, nil},
+		{"lower pattern ignores case", "prod", "PROD-cluster", true, []int{0, 1, 2, 3}},
+		{"shortest match", "ab", "a_xab", true, []int{3, 4}},
+		{"multibyte", "ねこ", "ねずみとねこ", true, []int{4, 5}},
+		{"not matched", "xyz", "prod-cluster", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := select5.FuzzyMatch(tt.pattern, tt.s)
+			if ok != tt.wantOk {
+				t.Fatalf("FuzzyMatch() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("FuzzyMatch() positions = %v, want %v", positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch_Score(t *testing.T) {
+	consecutive, _, _ := select5.FuzzyMatch("clu", "prod-cluster")
+	scattered, _, _ := select5.FuzzyMatch("clu", "cool-blue")
+	if consecutive <= scattered {
+		t.Errorf("consecutive match scored %d, want more than scattered match %d", consecutive, scattered)
+	}
+	boundary, _, _ := select5.FuzzyMatch("c", "prod-cluster")
+	inner, _, _ := select5.FuzzyMatch("c", "ticket")
+	if boundary <= inner {
+		t.Errorf("word boundary match scored %d, want more than inner match %d", boundary, inner)
+	}
+}
@@ -0,0 +1,65 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+)
+
+// SelectOf presents a list of any type for selection, showing each item with the label function.
+// It works like SelectString and returns the selected item and its index.
+// Returns the zero value and -1 with an error if:
+// - the provided slice is empty
+// - the keyboard event channel closes
+// - the user quits (ErrCanceled)
+func SelectOf[T any](items []T, label func(T) string) (T, int, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, -1, fmt.Errorf("SelectOf: %w", ErrEmptyList)
+	}
+
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = label(item)
+	}
+	indices, err := selectMenu(context.Background(), labels, SelectOptions{}, false)
+	if err != nil || indices == nil {
+		return zero, -1, err
+	}
+	return items[indices[0]], indices[0], nil
+}
+
+// SelectTableOf presents a list of any type as a table for selection.
+// Each column of the table is extracted from the item with the column function,
+// which returns a value supported by GetV.
+// It works like SelectTableRow and returns the selected item and its index.
+// Returns the zero value and -1 with an error if:
+// - the provided slice is empty
+// - no column function is provided
+// - the keyboard event channel closes
+// - the user quits (ErrCanceled)
+func SelectTableOf[T any](items []T, columns ...func(T) any) (T, int, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, -1, fmt.Errorf("SelectTableOf: %w", ErrEmptyList)
+	}
+	if len(columns) == 0 {
+		return zero, -1, fmt.Errorf("no column provided")
+	}
+
+	rows := make([][]any, len(items))
+	for i, item := range items {
+		rows[i] = make([]any, len(columns))
+		for j, column := range columns {
+			rows[i][j] = column(item)
+		}
+	}
+	t, err := newTable(nil, rows)
+	if err != nil {
+		return zero, -1, err
+	}
+	indices, err := selectTable(context.Background(), t, SelectOptions{}, false)
+	if err != nil || indices == nil {
+		return zero, -1, err
+	}
+	return items[indices[0]], indices[0], nil
+}
@@ -0,0 +1,120 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"os"
+	"testing"
+	"time"
+)
+
+type instance struct {
+	Name  string
+	Zone  string
+	Price float64
+}
+
+var instances = []instance{
+	{"web-1", "tokyo", 3.5},
+	{"web-2", "osaka", 3.2},
+	{"db-1", "tokyo", 12.8},
+}
+
+func TestSelectOf(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	type result struct {
+		item  instance
+		index int
+	}
+	resultCh := make(chan result)
+	go func() {
+		item, index, err := select5.SelectOf(instances, func(i instance) string {
+			return i.Name + " (" + i.Zone + ")"
+		})
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result{item, index}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	w.Write([]byte("db"))
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case res := <-resultCh:
+		if res.item != instances[2] || res.index != 2 {
+			t.Fatalf("Expected %v at 2 to be selected, got %v at %d", instances[2], res.item, res.index)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectTableOf(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	type result struct {
+		item  instance
+		index int
+	}
+	resultCh := make(chan result)
+	go func() {
+		item, index, err := select5.SelectTableOf(instances,
+			func(i instance) any { return i.Name },
+			func(i instance) any { return i.Zone },
+			func(i instance) any { return i.Price },
+		)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result{item, index}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case res := <-resultCh:
+		if res.item != instances[1] || res.index != 1 {
+			t.Fatalf("Expected %v at 1 to be selected, got %v at %d", instances[1], res.item, res.index)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectOfWithEmptyList(t *testing.T) {
+	if _, index, err := select5.SelectOf([]instance{}, func(i instance) string { return i.Name }); err == nil || index != -1 {
+		t.Fatalf("Expected error and -1, got %v and %d", err, index)
+	}
+	if _, index, err := select5.SelectTableOf([]instance{}, func(i instance) any { return i.Name }); err == nil || index != -1 {
+		t.Fatalf("Expected error and -1, got %v and %d", err, index)
+	}
+	if _, _, err := select5.SelectTableOf(instances); err == nil {
+		t.Fatal("Expected error for no column, got none")
+	}
+}
@@ -2,10 +2,10 @@ module github.com/g1eng/select5
 
 go 1.24.2
 
-require golang.org/x/term v0.31.0
-
 require (
-	github.com/mattn/go-runewidth v0.0.9 // indirect
-	github.com/olekukonko/tablewriter v0.0.5 // indirect
-	golang.org/x/sys v0.32.0 // indirect
+	github.com/mattn/go-runewidth v0.0.9
+	github.com/olekukonko/tablewriter v0.0.5
+	golang.org/x/term v0.31.0
 )
+
+require golang.org/x/sys v0.32.0
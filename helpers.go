@@ -1,7 +1,12 @@
 package select5
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"time"
 )
 
 // GetS extracts a string value from an any type.
@@ -150,204 +155,117 @@ func GetB(v any) (bool, error) {
 
 // GetV is a generic extract function for interface values.
 // Returns the value as string if it can be converted, or an error otherwise.
+// Supported values are:
+// - strings, []byte, ints, unsigned ints, floats and bools, including the named types of them
+// - nil and nil pointers (an empty string)
+// - time.Time (RFC 3339), time.Duration and json.Number
+// - error, fmt.Stringer and encoding.TextMarshaler, including the pointers implementing them
 func GetV(v any) (string, error) {
-	switch v.(type) {
-	case string:
-		return v.(string), nil
+	if v == nil {
+		return "", nil
+	}
+	r := reflect.ValueOf(v)
+	if r.Kind() == reflect.Pointer {
+		if r.IsNil() {
+			return "", nil
+		}
+		if text, ok, err := methodString(v); ok {
+			return text, err
+		}
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+
+	switch x := v.(type) {
 	case []byte:
-		return string(v.([]byte)), nil
-	case int:
-		return fmt.Sprintf("%d", v.(int)), nil
-	case int8:
-		return fmt.Sprintf("%d", v.(int8)), nil
-	case int16:
-		return fmt.Sprintf("%d", v.(int16)), nil
-	case int32:
-		return fmt.Sprintf("%d", v.(int32)), nil
-	case int64:
-		return fmt.Sprintf("%d", v.(int64)), nil
-	case float32:
-		return fmt.Sprintf("%f", v.(float32)), nil
-	case float64:
-		return fmt.Sprintf("%f", v.(float64)), nil
-	case bool:
-		if v.(bool) {
+		return string(x), nil
+	case time.Time:
+		return x.Format(time.RFC3339), nil
+	case time.Duration:
+		return x.String(), nil
+	}
+	if text, ok, err := methodString(v); ok {
+		return text, err
+	}
+	switch r.Kind() {
+	case reflect.String:
+		return r.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(r.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(r.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%f", r.Float()), nil
+	case reflect.Bool:
+		if r.Bool() {
 			return "✓", nil
-		} else {
-			return "", nil
 		}
-	default:
-		return "", fmt.Errorf("type %T not supported", v)
+		return "", nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+}
+
+// methodString returns the text of the value given by the Error, String or MarshalText method.
+// It returns false if the value implements none of them.
+func methodString(v any) (string, bool, error) {
+	switch x := v.(type) {
+	case error:
+		return x.Error(), true, nil
+	case fmt.Stringer:
+		return x.String(), true, nil
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		return string(text), true, err
 	}
+	return "", false, nil
 }
 
 // GetVP is a generic extract function for interface values for pointer types.
 // Returns the value as string if it can be converted, or an error otherwise.
+// It supports the values of GetV and the pointers to them.
 func GetVP(v any) (string, error) {
-	switch v.(type) {
-	case string:
-		p, err := GetS(v)
-		if err != nil {
-			return "", err
-		}
-		return p, nil
-	case *string:
-		p, err := GetS(v)
-		if err != nil {
-			return "", err
-		}
-		return p, nil
-	case []byte:
-		return string(v.([]byte)), nil
-	case int:
-		i, err := GetI(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case *int:
-		i, err := GetI(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case int8:
-		i, err := GetI8(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case *int8:
-		i, err := GetI8(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case int16:
-		i, err := GetI16(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case *int16:
-		i, err := GetI16(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case int32:
-		i, err := GetI32(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case *int32:
-		i, err := GetI32(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case int64:
-		i, err := GetI64(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case *int64:
-		i, err := GetI64(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%d", i), nil
-	case float32:
-		f, err := GetF32(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%f", f), nil
-	case *float32:
-		f, err := GetF32(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%f", f), nil
-	case float64:
-		f, err := GetF64(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%f", f), nil
-	case *float64:
-		f, err := GetF64(v)
-		if err != nil {
-			return "", err
-		}
-		return fmt.Sprintf("%f", f), nil
-	case bool:
-		b, err := GetB(v)
-		if err != nil {
-			return "", err
-		}
-		if b {
-			return "✓", nil
-		} else {
-			return "", nil
-		}
-	case *bool:
-		b, err := GetB(v)
-		if err != nil {
-			return "", err
-		}
-		if b {
-			return "✓", nil
-		} else {
-			return "", nil
+	r := reflect.ValueOf(v)
+	if r.Kind() != reflect.Pointer || r.IsNil() {
+		return GetV(v)
+	}
+	text, err := GetV(r.Elem().Interface())
+	if errors.Is(err, ErrUnsupportedType) {
+		// the methods may be implemented with the pointer receiver
+		if text, ok, err := methodString(v); ok {
+			return text, err
 		}
-	default:
-		return "", fmt.Errorf("type %T not supported", v)
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedType, v)
 	}
+	return text, err
 }
 
+// CheckPrimitive returns the type of the value in the bitmask of IsString, IsInt and so on, or IsAny if it is not supported.
+// A pointer has IsPointer with the type of the element (detected from the element type if it is nil), and nil has no type (0).
+// Values are classified by the way GetV shows them:
+// - named types by their underlying kind, and unsigned ints as ints (uint64 and uintptr as IsInt64)
+// - time.Duration as IsInt64, and json.Number as IsInt64 or IsFloat64
+// - []byte, time.Time, error, fmt.Stringer and encoding.TextMarshaler as IsString
 func CheckPrimitive(s any) (res byte) {
-	switch s.(type) {
-	case string:
-		res |= IsString
-	case int:
-		res |= IsInt
-	case int8:
-		res |= IsInt8
-	case int16:
-		res |= IsInt16
-	case int32:
-		res |= IsInt32
-	case int64:
-		res |= IsInt64
-	case float32:
-		res |= IsFloat32
-	case float64:
-		res |= IsFloat64
-	case bool:
-		res |= IsBool
-	case *string:
-		res |= IsPointer | IsString
-	case *int:
-		res |= IsPointer | IsInt
-	case *int8:
-		res |= IsPointer | IsInt8
-	case *int16:
-		res |= IsPointer | IsInt16
-	case *int32:
-		res |= IsPointer | IsInt32
-	case *int64:
-		res |= IsPointer | IsInt64
-	case *float32:
-		res |= IsPointer | IsFloat32
-	case *float64:
-		res |= IsPointer | IsFloat64
-	case *bool:
-		res |= IsPointer | IsBool
-	default:
-		res |= IsAny
+	kind, pointer, _ := valueKind(s)
+	res = kind.bits()
+	if pointer {
+		res |= IsPointer
+	}
+	return res
+}
+
+// textTypes are the interfaces of the values shown with the methods, for methodString
+var textTypes = []reflect.Type{
+	reflect.TypeFor[error](),
+	reflect.TypeFor[fmt.Stringer](),
+	reflect.TypeFor[encoding.TextMarshaler](),
+}
+
+// hasTextMethod returns true if the type implements one of textTypes
+func hasTextMethod(t reflect.Type) bool {
+	for _, i := range textTypes {
+		if t.Implements(i) {
+			return true
+		}
 	}
-	return
+	return false
 }
@@ -1,10 +1,14 @@
 package select5_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/g1eng/select5"
+	"net/netip"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -427,3 +431,96 @@ func TestGetVP(t *testing.T) {
 		})
 	}
 }
+
+type (
+	status   string
+	priority uint8
+	level    int
+)
+
+func (l level) String() string { return fmt.Sprintf("level-%d", int(l)) }
+
+type pointerStringer struct{ name string }
+
+func (p *pointerStringer) String() string { return "<" + p.name + ">" }
+
+func TestGetVP_Broadened(t *testing.T) {
+	stamp := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	var nilTime *time.Time
+	addr := netip.MustParseAddr("10.0.0.1")
+	typesTT := []struct {
+		name    string
+		v       any
+		wantRes string
+	}{
+		{"nil", nil, ""},
+		{"nil pointer", nilTime, ""},
+		{"uint", uint(7), "7"},
+		{"uint8", uint8(255), "255"},
+		{"uint64", uint64(18446744073709551615), "18446744073709551615"},
+		{"time.Time", stamp, "2024-05-06T07:08:09Z"},
+		{"*time.Time", &stamp, "2024-05-06T07:08:09Z"},
+		{"time.Duration", 90 * time.Second, "1m30s"},
+		{"error", errors.New("broken"), "broken"},
+		{"fmt.Stringer", level(3), "level-3"},
+		{"pointer receiver", &pointerStringer{"p"}, "<p>"},
+		{"encoding.TextMarshaler", addr, "10.0.0.1"},
+		{"json.Number", json.Number("12.50"), "12.50"},
+		{"named string", status("ready"), "ready"},
+		{"named uint8", priority(2), "2"},
+		{"named pointer", &[]status{"done"}[0], "done"},
+	}
+	for _, tt := range typesTT {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRes, err := select5.GetVP(tt.v)
+			if err != nil {
+				t.Fatalf("GetVP() failed: %v", err)
+			}
+			if gotRes != tt.wantRes {
+				t.Errorf("GetVP() = %q, want %q", gotRes, tt.wantRes)
+			}
+			if !strings.HasPrefix(tt.name, "*") && tt.name != "named pointer" {
+				if gotRes, _ := select5.GetV(tt.v); gotRes != tt.wantRes {
+					t.Errorf("GetV() = %q, want %q", gotRes, tt.wantRes)
+				}
+			}
+		})
+	}
+	if _, err := select5.GetVP([]int{1}); !errors.Is(err, select5.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType for a slice, got %v", err)
+	}
+}
+
+func TestCheckPrimitive_Broadened(t *testing.T) {
+	var nilDuration *time.Duration
+	typesTT := []struct {
+		name    string
+		v       any
+		wantRes byte
+	}{
+		{"nil", nil, 0},
+		{"nil pointer", pNilInt, select5.IsInt | select5.IsPointer},
+		{"nil *time.Duration", nilDuration, select5.IsInt64 | select5.IsPointer},
+		{"uint", uint(1), select5.IsInt},
+		{"uint32", uint32(1), select5.IsInt32},
+		{"uint64", uint64(1), select5.IsInt64},
+		{"time.Time", time.Now(), select5.IsString},
+		{"time.Duration", time.Second, select5.IsInt64},
+		{"error", errors.New("e"), select5.IsString | select5.IsPointer}, // *errors.errorString
+		{"fmt.Stringer", level(1), select5.IsString},
+		{"pointer receiver", &pointerStringer{}, select5.IsString | select5.IsPointer},
+		{"encoding.TextMarshaler", netip.MustParseAddr("::1"), select5.IsString},
+		{"json.Number int", json.Number("12"), select5.IsInt64},
+		{"json.Number float", json.Number("1.5"), select5.IsFloat64},
+		{"named string", status("ok"), select5.IsString},
+		{"named uint8", priority(1), select5.IsInt8},
+		{"slice", []int{1}, select5.IsAny},
+	}
+	for _, tt := range typesTT {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotRes := select5.CheckPrimitive(tt.v); gotRes != tt.wantRes {
+				t.Errorf("CheckPrimitive() = %#x, want %#x", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
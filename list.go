@@ -0,0 +1,85 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// KeyValue is an entry of a map chosen with Selector
+type KeyValue struct {
+	Key   any
+	Value any
+}
+
+// selectList presents a slice of primitives (like []any, []int or []float64) for selection.
+// It returns the selected element, or a slice of the same type with the marked elements if Multi is set.
+func (s *Selector) selectList(ctx context.Context, v reflect.Value, opts SelectOptions) (any, error) {
+	if v.Len() == 0 {
+		return nil, fmt.Errorf("Select: %w", ErrEmptyList)
+	}
+	labels := make([]string, v.Len())
+	for i := range labels {
+		label, err := GetVP(v.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		labels[i] = label
+	}
+	item := func(index int) any { return v.Index(index).Interface() }
+	indices, err := selectItems(ctx, labels, item, opts, s.Multi)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	if !s.Multi {
+		return item(indices[0]), nil
+	}
+	res := reflect.MakeSlice(v.Type(), 0, len(indices))
+	for _, i := range indices {
+		res = reflect.Append(res, v.Index(i))
+	}
+	return res.Interface(), nil
+}
+
+// selectMap presents a map as a table of the keys and the values, sorted by the keys.
+// It returns the KeyValue of the selected entry, or a []KeyValue of the marked entries if Multi is set.
+func (s *Selector) selectMap(ctx context.Context, v reflect.Value, opts SelectOptions) (any, error) {
+	if v.Len() == 0 {
+		return nil, fmt.Errorf("Select: %w", ErrEmptyList)
+	}
+	keys := sortedKeys(v)
+	entries := make([]KeyValue, len(keys))
+	rows := make([][]any, len(keys))
+	for i, k := range keys {
+		entries[i] = KeyValue{Key: k.Interface(), Value: v.MapIndex(k).Interface()}
+		rows[i] = []any{entries[i].Key, entries[i].Value}
+	}
+	t, err := newTable(opts.Header, rows)
+	if err != nil {
+		return nil, err
+	}
+	t.items = func(index int) any { return entries[index] }
+
+	indices, err := selectTable(ctx, t, opts, s.Multi)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	if !s.Multi {
+		return entries[indices[0]], nil
+	}
+	var res []KeyValue
+	for _, i := range indices {
+		res = append(res, entries[i])
+	}
+	return res, nil
+}
+
+// sortedKeys returns the keys of the map, sorted in the order of the table cells
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return compareCells(keys[i].Interface(), keys[j].Interface()) < 0
+	})
+	return keys
+}
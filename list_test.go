@@ -0,0 +1,68 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelector_SelectList(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want any
+	}{
+		{"NewSelectorFrom", select5.NewSelectorFrom([]any{"a", 2, 3.5}).Data, 2},
+		{"[]int", []int{10, 20, 30}, 20},
+		{"[]float64", []float64{0.5, 1.5}, 1.5},
+		{"[]bool", []bool{false, true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := selectWithOutput(t, [][]byte{{0x1b, '[', 'B'}, {0x0a}}, func() (any, error) {
+				return (&select5.Selector{Data: tt.data}).Select()
+			})
+			if got != tt.want {
+				t.Errorf("Select() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelector_SelectListMulti(t *testing.T) {
+	keys := [][]byte{{' '}, {0x1b, '[', 'B'}, {0x1b, '[', 'B'}, {' '}, {0x0a}}
+	got, _ := selectWithOutput(t, keys, func() (any, error) {
+		return (&select5.Selector{Data: []int{1, 2, 3}, Multi: true}).Select()
+	})
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Select() = %#v, want []int{1, 3}", got)
+	}
+}
+
+func TestSelector_SelectMap(t *testing.T) {
+	data := map[string]int{"web-10": 3, "web-9": 1, "db": 2}
+	got, out := selectWithOutput(t, [][]byte{{0x1b, '[', 'B'}, {0x0a}}, func() (any, error) {
+		return (&select5.Selector{Header: []string{"HOST", "CPU"}, Data: data}).Select()
+	})
+	// the keys are sorted with the numbers in the numeric order
+	if want := (select5.KeyValue{Key: "web-9", Value: 1}); got != want {
+		t.Fatalf("Select() = %#v, want %#v", got, want)
+	}
+	if db, web9, web10 := strings.Index(out, "db"), strings.Index(out, "web-9"), strings.Index(out, "web-10"); db > web9 || web9 > web10 {
+		t.Errorf("expected the sorted keys in the output %q", out)
+	}
+}
+
+func TestSelector_SelectListErrors(t *testing.T) {
+	if _, err := (&select5.Selector{Data: []int{}}).Select(); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("expected ErrEmptyList, got %v", err)
+	}
+	if _, err := (&select5.Selector{Data: map[int]int{}}).Select(); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("expected ErrEmptyList, got %v", err)
+	}
+	if _, err := (&select5.Selector{Data: [][]int{{1}}}).Select(); !errors.Is(err, select5.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package select5
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MouseButton is the button of a mouse event, in the code of the SGR mouse mode
+type MouseButton int
+
+// Buttons of the mouse events
+const (
+	MouseLeft       MouseButton = 0
+	MouseMiddle     MouseButton = 1
+	MouseRight      MouseButton = 2
+	MouseNone       MouseButton = 3 // no button is pressed while the pointer moves
+	MouseWheelUp    MouseButton = 64
+	MouseWheelDown  MouseButton = 65
+	MouseWheelLeft  MouseButton = 66
+	MouseWheelRight MouseButton = 67
+)
+
+// MouseEvent represents a mouse input event, reported when the mouse mode is enabled.
+// The modifiers are set in Ctrl, Alt and Shift of the KeyEvent.
+type MouseEvent struct {
+	Button  MouseButton // The button pressed or released, or the wheel turned
+	X       int         // Column of the pointer, starting from 1
+	Y       int         // Line of the pointer, starting from 1
+	Release bool        // Whether the button is released
+	Motion  bool        // Whether the pointer is moved
+}
+
+// doubleClickInterval is the maximum pause between the clicks of a double click
+const doubleClickInterval = 400 * time.Millisecond
+
+// wheelLines is the number of the lines scrolled by a turn of the wheel
+const wheelLines = 3
+
+// parseMouse decodes the SGR mouse sequence "ESC [ < button ; x ; y M" (or "m" for a release).
+// Returns false if the sequence is malformed.
+func parseMouse(seq []byte) (KeyEvent, bool) {
+	if len(seq) < 4 || string(seq[:3]) != "\x1b[<" {
+		return KeyEvent{}, false
+	}
+	final := seq[len(seq)-1]
+	fields := strings.Split(string(seq[3:len(seq)-1]), ";")
+	if len(fields) != 3 || (final != 'M' && final != 'm') {
+		return KeyEvent{}, false
+	}
+	var n [3]int
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return KeyEvent{}, false
+		}
+		n[i] = v
+	}
+	code := n[0]
+	return KeyEvent{
+		Code:    code,
+		Special: MOUSE,
+		Shift:   code&4 != 0,
+		Alt:     code&8 != 0,
+		Ctrl:    code&16 != 0,
+		Mouse: &MouseEvent{
+			// the modifiers and the motion flag are removed from the button code
+			Button:  MouseButton(code &^ (4 | 8 | 16 | 32)),
+			X:       n[1],
+			Y:       n[2],
+			Release: final == 'm',
+			Motion:  code&32 != 0,
+		},
+	}, true
+}
+
+// mouse moves the cursor to the clicked item, or scrolls the page with the wheel.
+// It returns true for a double click on an item, which is accepted like ENTER.
+// Clicks are handled in FullScreen and AltScreen, where the lines of the frame are known on the screen.
+func (s *session) mouse(m *MouseEvent) bool {
+	switch {
+	case m.Motion || m.Release:
+	case m.Button == MouseWheelUp:
+		s.wheel(-wheelLines)
+	case m.Button == MouseWheelDown:
+		s.wheel(wheelLines)
+	case m.Button == MouseLeft && s.opts.Screen != InlineScreen:
+		if s.hasPreview() && m.X > s.screenWidth() {
+			return false
+		}
+		from, to := s.window()
+		i := from + m.Y - 1 - s.top
+		if m.Y-1 < s.top || i >= to {
+			return false
+		}
+		now := time.Now()
+		double := i == s.cursor && now.Sub(s.lastClick) < doubleClickInterval
+		s.cursor = i
+		s.lastClick = now
+		if double {
+			s.lastClick = time.Time{}
+			return true
+		}
+	}
+	return false
+}
+
+// wheel scrolls the page by delta lines, keeping the cursor in the page
+func (s *session) wheel(delta int) {
+	s.offset += delta
+	if max := len(s.view) - s.pageSize(); s.offset > max {
+		s.offset = max
+	}
+	if s.offset < 0 {
+		s.offset = 0
+	}
+	if s.cursor < s.offset {
+		s.cursor = s.offset
+	} else if last := s.offset + s.pageSize() - 1; s.cursor > last {
+		s.cursor = last
+	}
+	if s.cursor >= len(s.view) {
+		s.cursor = len(s.view) - 1
+	}
+}
@@ -0,0 +1,133 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCaptureKeyboardEvents_Mouse(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	keyChannel, _ := select5.CaptureKeyboardEvents()
+	// ctrl+left press at (12, 5), its release, a wheel down, and a key after them
+	if _, err := w.Write([]byte("\x1b[<16;12;5M\x1b[<16;12;5m\x1b[<65;1;1Mx")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []select5.MouseEvent{
+		{Button: select5.MouseLeft, X: 12, Y: 5},
+		{Button: select5.MouseLeft, X: 12, Y: 5, Release: true},
+		{Button: select5.MouseWheelDown, X: 1, Y: 1},
+	}
+	for i, e := range expected {
+		select {
+		case k := <-keyChannel:
+			if k.Special != select5.MOUSE || k.Mouse == nil {
+				t.Fatalf("expected a mouse event, got %+v", k)
+			}
+			if *k.Mouse != e {
+				t.Errorf("event %d: expected %+v, got %+v", i, e, *k.Mouse)
+			}
+			if k.Ctrl != (i < 2) {
+				t.Errorf("event %d: unexpected Ctrl modifier %v", i, k.Ctrl)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for mouse event")
+		}
+	}
+	select {
+	case k := <-keyChannel:
+		if k.Key != 'x' {
+			t.Errorf("expected the key after the mouse events, got %+v", k)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for key event")
+	}
+}
+
+func TestSelectOptions_MouseDoubleClick(t *testing.T) {
+	opts := select5.SelectOptions{Mouse: true}
+	// the filter line is at line 1, and the items start from line 2
+	click := []byte("\x1b[<0;5;4M\x1b[<0;5;4m")
+	keys := [][]byte{append(click, click...)}
+	result, out := selectWithOutput(t, keys, func() (string, error) {
+		return select5.SelectStringWith([]string{"alpha", "beta", "gamma"}, opts)
+	})
+	if result != "gamma" {
+		t.Fatalf("Expected 'gamma' to be chosen with a double click, got '%s'", result)
+	}
+	if !strings.HasPrefix(out, select5.EnableMouse) {
+		t.Errorf("expected the mouse mode to be enabled in the output %q", out)
+	}
+	if !strings.HasSuffix(out, select5.DisableMouse) {
+		t.Errorf("expected the mouse mode to be disabled at the end of the output %q", out)
+	}
+}
+
+func TestSelectOptions_MouseClickAndWheel(t *testing.T) {
+	items := make([]string, 100)
+	for i := range items {
+		items[i] = strings.Repeat("x", i+1)
+	}
+	opts := select5.SelectOptions{Mouse: true}
+	// a click on the second item and the wheel down twice, which scrolls the cursor to the first item of the page
+	keys := [][]byte{[]byte("\x1b[<0;3;3M\x1b[<0;3;3m"), []byte("\x1b[<65;3;3M\x1b[<65;3;3M"), {0x0a}}
+	result, _ := selectWithOutput(t, keys, func() (string, error) {
+		return select5.SelectStringWith(items, opts)
+	})
+	if result != items[6] {
+		t.Fatalf("Expected '%s' to be selected after the wheel, got '%s'", items[6], result)
+	}
+}
+
+func TestSelectOptions_MouseCanceled(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outR.Close()
+
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = r, outW
+	defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte{0x1b}) // ESC
+	}()
+	_, err = select5.SelectStringWith([]string{"alpha"}, select5.SelectOptions{Mouse: true})
+	outW.Close()
+	if err == nil {
+		t.Fatal("expected the selection to be canceled")
+	}
+	buf := make([]byte, 4096)
+	var out strings.Builder
+	for {
+		n, err := outR.Read(buf)
+		out.Write(buf[:n])
+		if err != nil {
+			break
+		}
+	}
+	if !strings.HasSuffix(out.String(), select5.DisableMouse) {
+		t.Errorf("expected the mouse mode to be disabled on cancel in the output %q", out.String())
+	}
+}
@@ -0,0 +1,67 @@
+package select5
+
+import (
+	"context"
+	"github.com/mattn/go-runewidth"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultCursor is the cursor glyph of the list selection
+const DefaultCursor = "> "
+
+// SelectOptions configures the appearance and the behavior of a selection.
+// The zero value is the default configuration.
+type SelectOptions struct {
+	Default       int            // index of the item under the cursor at the start
+	DefaultValue  any            // item under the cursor at the start, which has priority over Default if it is found in the list
+	Prompt        string         // question shown above the list
+	Footer        string         // help line shown below the list
+	NoWrap        bool           // stop the cursor at the ends of the list, instead of wrapping around
+	Cursor        string         // cursor glyph (the one of the theme if empty)
+	Header        []string       // header of the table (table selection only)
+	IdleTimeout   time.Duration  // accept the default item if no key is pressed for the duration (no timeout if zero)
+	Screen        ScreenMode     // way to draw the selection (FullScreen by default)
+	Height        int            // maximum number of the lines in InlineScreen (the terminal height if zero)
+	Summary       bool           // leave the line like "✔ picked: foo" after the selection
+	Theme         *Theme         // appearance of the selection (DefaultTheme if nil)
+	Disabled      []int          // indices of the items which are shown, but cannot be chosen, even on the idle timeout
+	ColumnWidths  []int          // maximum width of each column of the table, or 0 for no limit
+	ColumnFormats []ColumnFormat // format of each column of the table
+	Mouse         bool           // whether a click moves the cursor, a double click accepts the item and the wheel scrolls
+
+	Preview         func(item any) string                                  // text of the preview pane for the item under the cursor
+	PreviewWriter   func(ctx context.Context, item any, w io.Writer) error // writes the preview, and stops when ctx is canceled (used instead of Preview)
+	PreviewPosition PreviewPosition                                        // place of the preview pane (PreviewAuto by default)
+	PreviewSize     int                                                    // percentage of the terminal width or height for the preview pane (50 if zero)
+}
+
+// defaultIndex returns the index of the item to place the cursor on at the start
+func (o SelectOptions) defaultIndex(n int, item func(i int) any) int {
+	if o.DefaultValue != nil {
+		for i := 0; i < n; i++ {
+			if reflect.DeepEqual(item(i), o.DefaultValue) {
+				return i
+			}
+		}
+	}
+	if o.Default >= 0 && o.Default < n {
+		return o.Default
+	}
+	return 0
+}
+
+// lines splits the text to lines, or returns nil for an empty text
+func lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// blank returns spaces of the same width as the text
+func blank(text string) string {
+	return strings.Repeat(" ", runewidth.StringWidth(text))
+}
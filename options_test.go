@@ -0,0 +1,144 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSelectStringWith(t *testing.T) {
+	list := []string{"small", "medium", "large", "xlarge"}
+
+	tests := []struct {
+		name     string
+		opts     select5.SelectOptions
+		keys     [][]byte
+		expected string
+	}{
+		{"default index", select5.SelectOptions{Default: 2}, nil, "large"},
+		{"default value", select5.SelectOptions{Default: 2, DefaultValue: "medium"}, nil, "medium"},
+		{"default out of range", select5.SelectOptions{Default: 10}, nil, "small"},
+		{"wrap", select5.SelectOptions{}, [][]byte{{0x1b, '[', 'A'}}, "xlarge"},
+		{"no wrap", select5.SelectOptions{NoWrap: true}, [][]byte{{0x1b, '[', 'A'}}, "small"},
+		{"no wrap at the end", select5.SelectOptions{Default: 3, NoWrap: true}, [][]byte{{0x1b, '[', 'B'}}, "xlarge"},
+		{"prompt and footer", select5.SelectOptions{Prompt: "Instance size?\n(choose one)", Footer: "↑/↓: move", Cursor: "➜ "}, [][]byte{{0x1b, '[', 'B'}}, "medium"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+
+			resultCh := make(chan string)
+			go func() {
+				result, err := select5.SelectStringWith(list, tt.opts)
+				if err != nil {
+					panic(err)
+				}
+				resultCh <- result
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			for _, key := range tt.keys {
+				w.Write(key)
+				time.Sleep(100 * time.Millisecond)
+			}
+			w.Write([]byte{0x0a})
+
+			select {
+			case result := <-resultCh:
+				if result != tt.expected {
+					t.Fatalf("Expected '%s' to be selected, got '%s'", tt.expected, result)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for selection")
+			}
+		})
+	}
+}
+
+func TestSelector_Select_Options(t *testing.T) {
+	data := [][]any{
+		{"a", 1, true},
+		{"b", 2, false},
+		{"c", 3, true},
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan any)
+	go func() {
+		s := select5.Selector{
+			Data: data,
+			Options: select5.SelectOptions{
+				DefaultValue: []any{"b", 2, false},
+				Prompt:       "Pick a row",
+				Header:       []string{"CODE", "NUM", "FLAG"},
+				NoWrap:       true,
+			},
+		}
+		result, err := s.Select()
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow, which stops at the last row
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x0a})
+
+	select {
+	case result := <-resultCh:
+		if !rowsEqual(result.([]any), data[2]) {
+			t.Fatalf("Expected %v to be selected, got %v", data[2], result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectOptions_DefaultValueOfItems(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		def  any
+	}{
+		{"struct", servers, servers[2]},
+		{"struct pointer", []*server{&servers[0], &servers[1]}, &servers[1]},
+		{"map", map[string]int{"x": 1, "y": 2, "z": 3}, select5.KeyValue{Key: "y", Value: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := selectWithOutput(t, [][]byte{{0x0a}}, func() (any, error) {
+				s := select5.Selector{Data: tt.data, Options: select5.SelectOptions{DefaultValue: tt.def}}
+				return s.Select()
+			})
+			if !reflect.DeepEqual(got, tt.def) {
+				t.Errorf("Select() = %#v, want the default %#v", got, tt.def)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// previewDelay is the pause after a cursor move before the preview is started
+const previewDelay = 50 * time.Millisecond
+
+// PreviewPosition is the place of the preview pane
+type PreviewPosition int
+
+// Positions of the preview pane
+const (
+	PreviewAuto   PreviewPosition = iota // right of the items if the terminal is wide enough (80 columns), otherwise below them
+	PreviewRight                         // right of the items
+	PreviewBottom                        // below the items
+)
+
+// minPreviewWidth is the terminal width to put the preview pane right of the items with PreviewAuto
+const minPreviewWidth = 80
+
+// previewRequest asks the preview of the item of the original index
+type previewRequest struct {
+	index int
+	item  any
+}
+
+// previewUpdate is the preview text of the item of the original index
+type previewUpdate struct {
+	index int
+	text  string
+}
+
+// hasPreview returns true if the preview pane is configured
+func (s *session) hasPreview() bool {
+	return s.opts.Preview != nil || s.opts.PreviewWriter != nil
+}
+
+// startPreview starts the goroutine which makes the previews for the requests, until done is closed.
+// A request is started after previewDelay without another request, and the running preview is canceled by a new request.
+// It returns the channel of the preview texts, which are sent again while a PreviewWriter writes.
+func (s *session) startPreview(done <-chan struct{}) <-chan previewUpdate {
+	requests := make(chan previewRequest, 1)
+	updates := make(chan previewUpdate, 1)
+	s.previewRequests = requests
+	s.previewIndex = -1
+
+	go func() {
+		cancel := context.CancelFunc(func() {})
+		defer func() { cancel() }()
+		var timer <-chan time.Time
+		var pending previewRequest
+		for {
+			select {
+			case <-done:
+				return
+			case pending = <-requests:
+				cancel()
+				timer = time.After(previewDelay)
+			case <-timer:
+				timer = nil
+				if pending.index < 0 {
+					continue
+				}
+				ctx, stop := context.WithCancel(context.Background())
+				cancel = stop
+				go s.preview(ctx, pending, updates)
+			}
+		}
+	}()
+	return updates
+}
+
+// preview makes the preview text of the item and sends it, unless the context is canceled
+func (s *session) preview(ctx context.Context, req previewRequest, updates chan<- previewUpdate) {
+	send := func(text string) {
+		select {
+		case updates <- previewUpdate{index: req.index, text: text}:
+		case <-ctx.Done():
+		}
+	}
+	if s.opts.PreviewWriter == nil {
+		text := s.opts.Preview(req.item)
+		if ctx.Err() == nil {
+			send(text)
+		}
+		return
+	}
+	w := &previewWriter{send: send}
+	if err := s.opts.PreviewWriter(ctx, req.item, w); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(w, "\n(%s)", err)
+	}
+}
+
+// previewWriter sends the text written so far for each write
+type previewWriter struct {
+	mu   sync.Mutex
+	buf  strings.Builder
+	send func(text string)
+}
+
+// Write appends the data to the preview text
+func (w *previewWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf.Write(p)
+	text := w.buf.String()
+	w.mu.Unlock()
+	w.send(text)
+	return len(p), nil
+}
+
+// requestPreview asks the preview of the item under the cursor, if it has changed
+func (s *session) requestPreview() {
+	index := s.selected()
+	if index == s.previewIndex {
+		return
+	}
+	s.previewIndex = index
+	s.previewText = ""
+	req := previewRequest{index: index}
+	if index >= 0 {
+		req.item = s.item(index)
+	}
+	// drop the request which has not been received yet
+	select {
+	case <-s.previewRequests:
+	default:
+	}
+	s.previewRequests <- req
+}
+
+// updatePreview sets the preview text if it is for the item under the cursor
+func (s *session) updatePreview(u previewUpdate) bool {
+	if u.index != s.previewIndex {
+		return false
+	}
+	s.previewText = u.text
+	return true
+}
+
+// previewLayout returns the position and the size of the preview pane.
+// The size is the width for PreviewRight, and the height for PreviewBottom.
+func (s *session) previewLayout() (PreviewPosition, int) {
+	width, height := terminalSize()
+	percent := s.opts.PreviewSize
+	if percent <= 0 || percent >= 100 {
+		percent = 50
+	}
+	position := s.opts.PreviewPosition
+	if position == PreviewAuto {
+		position = PreviewRight
+		if width < minPreviewWidth {
+			position = PreviewBottom
+		}
+	}
+	if position == PreviewRight {
+		return position, width * percent / 100
+	}
+	return position, height * percent / 100
+}
+
+// withPreview puts the preview pane right of or below the lines of the frame
+func (s *session) withPreview(frame []string) []string {
+	position, size := s.previewLayout()
+	width, _ := terminalSize()
+	var preview []string
+	for _, line := range strings.Split(strings.TrimRight(s.previewText, "\n"), "\n") {
+		preview = append(preview, expandTabs(line))
+	}
+	border := s.theme().Border
+
+	if position == PreviewBottom {
+		res := append([]string{}, frame...)
+		res = append(res, border.render(strings.Repeat("─", width), s.profile))
+		for i := 0; i < size-1; i++ {
+			line := ""
+			if i < len(preview) {
+				line = preview[i]
+			}
+			res = append(res, fitWidth(line, width))
+		}
+		return res
+	}
+
+	listWidth := s.screenWidth()
+	rows := len(frame)
+	if s.opts.Screen != InlineScreen {
+		rows = s.screenHeight()
+	}
+	res := make([]string, rows)
+	for i := range res {
+		line, p := "", ""
+		if i < len(frame) {
+			line = frame[i]
+		}
+		if i < len(preview) {
+			p = preview[i]
+		}
+		res[i] = fitWidth(line, listWidth) + border.render(" │ ", s.profile) + fitWidth(p, size)
+	}
+	return res
+}
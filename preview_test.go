@@ -0,0 +1,75 @@
+package select5_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/g1eng/select5"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSelectOptions_Preview(t *testing.T) {
+	opts := select5.SelectOptions{
+		Preview: func(item any) string {
+			return fmt.Sprintf("details of %s\n\tsize: %d", item, len(item.(string)))
+		},
+	}
+	keys := [][]byte{{0x1b, '[', 'B'}, {}, {0x0a}} // DOWN, wait for the preview, ENTER
+	result, out := selectWithOutput(t, keys, func() (string, error) {
+		return select5.SelectStringWith([]string{"alpha", "beta"}, opts)
+	})
+	if result != "beta" {
+		t.Fatalf("Expected 'beta' to be selected, got '%s'", result)
+	}
+	for _, s := range []string{" │ ", "details of alpha", "details of beta", "        size: 4"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+}
+
+func TestSelectOptions_PreviewWriter(t *testing.T) {
+	var canceled atomic.Int32
+	opts := select5.SelectOptions{
+		PreviewPosition: select5.PreviewBottom,
+		PreviewWriter: func(ctx context.Context, item any, w io.Writer) error {
+			row := item.([]any)
+			fmt.Fprintf(w, "log of %s\n", row[0])
+			if row[0] == "slow" {
+				select {
+				case <-ctx.Done():
+					canceled.Add(1)
+					return ctx.Err()
+				case <-time.After(5 * time.Second):
+				}
+			}
+			fmt.Fprintf(w, "done %s", row[0])
+			return nil
+		},
+	}
+	keys := [][]byte{{}, {0x1b, '[', 'B'}, {}, {0x0a}} // wait for the slow preview, DOWN, wait, ENTER
+	start := time.Now()
+	row, out := selectWithOutput(t, keys, func() ([]any, error) {
+		return select5.SelectTableRowWith([][]any{{"slow", 1}, {"fast", 2}}, opts)
+	})
+	if row[0] != "fast" {
+		t.Fatalf("Expected 'fast' to be selected, got '%s'", row[0])
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Fatalf("the slow preview blocked the selection for %s", time.Since(start))
+	}
+	if canceled.Load() != 1 {
+		t.Errorf("expected the slow preview to be canceled once, got %d", canceled.Load())
+	}
+	for _, s := range []string{"───", "log of slow", "done fast"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+	if strings.Contains(out, "done slow") {
+		t.Errorf("unexpected preview of the canceled item in the output %q", out)
+	}
+}
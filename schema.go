@@ -0,0 +1,299 @@
+package select5
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Kind is the type of the values in a column, with a distinct kind for every Go numeric type
+type Kind int
+
+// Kinds of the values.
+// Named types are classified by their underlying kind, unless they are shown with a method (KindText).
+const (
+	KindNone       Kind = iota // no value, only nil
+	KindString                 // string
+	KindBytes                  // []byte
+	KindBool                   // bool
+	KindInt                    // int
+	KindInt8                   // int8
+	KindInt16                  // int16
+	KindInt32                  // int32
+	KindInt64                  // int64
+	KindUint                   // uint
+	KindUint8                  // uint8
+	KindUint16                 // uint16
+	KindUint32                 // uint32
+	KindUint64                 // uint64
+	KindUintptr                // uintptr
+	KindFloat32                // float32
+	KindFloat64                // float64
+	KindComplex64              // complex64
+	KindComplex128             // complex128
+	KindTime                   // time.Time
+	KindDuration               // time.Duration
+	KindText                   // error, fmt.Stringer or encoding.TextMarshaler
+	KindMixed                  // two or more kinds in a column
+	KindAny                    // other types, which cannot be shown
+)
+
+// kindNames are the names of the kinds for String
+var kindNames = [...]string{
+	KindNone:       "none",
+	KindString:     "string",
+	KindBytes:      "[]byte",
+	KindBool:       "bool",
+	KindInt:        "int",
+	KindInt8:       "int8",
+	KindInt16:      "int16",
+	KindInt32:      "int32",
+	KindInt64:      "int64",
+	KindUint:       "uint",
+	KindUint8:      "uint8",
+	KindUint16:     "uint16",
+	KindUint32:     "uint32",
+	KindUint64:     "uint64",
+	KindUintptr:    "uintptr",
+	KindFloat32:    "float32",
+	KindFloat64:    "float64",
+	KindComplex64:  "complex64",
+	KindComplex128: "complex128",
+	KindTime:       "time",
+	KindDuration:   "duration",
+	KindText:       "text",
+	KindMixed:      "mixed",
+	KindAny:        "any",
+}
+
+// String returns the name of the kind
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// reflectKinds are the kinds of the primitive reflect kinds
+var reflectKinds = map[reflect.Kind]Kind{
+	reflect.String:     KindString,
+	reflect.Bool:       KindBool,
+	reflect.Int:        KindInt,
+	reflect.Int8:       KindInt8,
+	reflect.Int16:      KindInt16,
+	reflect.Int32:      KindInt32,
+	reflect.Int64:      KindInt64,
+	reflect.Uint:       KindUint,
+	reflect.Uint8:      KindUint8,
+	reflect.Uint16:     KindUint16,
+	reflect.Uint32:     KindUint32,
+	reflect.Uint64:     KindUint64,
+	reflect.Uintptr:    KindUintptr,
+	reflect.Float32:    KindFloat32,
+	reflect.Float64:    KindFloat64,
+	reflect.Complex64:  KindComplex64,
+	reflect.Complex128: KindComplex128,
+}
+
+// bits returns the type of the kind in the bitmask of CheckPrimitive
+func (k Kind) bits() byte {
+	switch k {
+	case KindNone:
+		return 0
+	case KindString, KindBytes, KindTime, KindText:
+		return IsString
+	case KindBool:
+		return IsBool
+	case KindInt, KindUint:
+		return IsInt
+	case KindInt8, KindUint8:
+		return IsInt8
+	case KindInt16, KindUint16:
+		return IsInt16
+	case KindInt32, KindUint32:
+		return IsInt32
+	case KindInt64, KindUint64, KindUintptr, KindDuration:
+		return IsInt64
+	case KindFloat32:
+		return IsFloat32
+	case KindFloat64:
+		return IsFloat64
+	}
+	return IsAny
+}
+
+// valueKind returns the kind of the value, whether it is a pointer, and whether it is nil or a nil pointer.
+// The kind of a nil pointer is detected from the type of the element, and a json.Number is classified by its value.
+func valueKind(v any) (kind Kind, pointer bool, null bool) {
+	if v == nil {
+		return KindNone, false, true
+	}
+	r := reflect.ValueOf(v)
+	if r.Kind() == reflect.Pointer {
+		elem := r.Elem()
+		if r.IsNil() {
+			elem = reflect.Zero(r.Type().Elem())
+		}
+		kind, _, _ = valueKind(elem.Interface())
+		if kind == KindAny && hasTextMethod(r.Type()) {
+			// the methods are implemented with the pointer receiver
+			kind = KindText
+		}
+		return kind, true, r.IsNil()
+	}
+
+	switch x := v.(type) {
+	case time.Time:
+		return KindTime, false, false
+	case time.Duration:
+		return KindDuration, false, false
+	case json.Number:
+		if _, err := x.Int64(); err == nil {
+			return KindInt64, false, false
+		}
+		return KindFloat64, false, false
+	case []byte:
+		return KindBytes, false, false
+	case error, fmt.Stringer, encoding.TextMarshaler:
+		return KindText, false, false
+	}
+	if kind, ok := reflectKinds[r.Kind()]; ok {
+		return kind, false, false
+	}
+	return KindAny, false, false
+}
+
+// ColumnType is the type of the values in a column of a table, or in a list
+type ColumnType struct {
+	Name     string // header of the column, or empty if the selector has no header
+	Kind     Kind   // kind of the values, KindMixed for two or more kinds, or KindNone if the column has no value
+	Kinds    []Kind // kinds detected in the column, in the order of appearance
+	Nullable bool   // whether the column has nil, a nil pointer, or a row without the column
+	Pointer  bool   // whether the column has pointers
+}
+
+// Mixed returns true if the column has two or more kinds of values
+func (c ColumnType) Mixed() bool {
+	return len(c.Kinds) > 1
+}
+
+// add classifies a value of the column
+func (c *ColumnType) add(v any) {
+	kind, pointer, null := valueKind(v)
+	c.Pointer = c.Pointer || pointer
+	c.Nullable = c.Nullable || null
+	if kind == KindNone {
+		return
+	}
+	for _, k := range c.Kinds {
+		if k == kind {
+			return
+		}
+	}
+	c.Kinds = append(c.Kinds, kind)
+	c.Kind = kind
+	if len(c.Kinds) > 1 {
+		c.Kind = KindMixed
+	}
+}
+
+// bits returns the types of the values in the bitmask of CheckPrimitive
+func (c ColumnType) bits() (res byte) {
+	for _, k := range c.Kinds {
+		res |= k.bits()
+	}
+	if c.Pointer {
+		res |= IsPointer
+	}
+	return res
+}
+
+// Schema is the types of the columns of the data in a Selector.
+// A list has one column.
+type Schema struct {
+	Table   bool         // whether the data is a table
+	Columns []ColumnType // types of the columns
+}
+
+// Schema returns the types of the columns of the data, detected from all the values.
+// It supports lists ([]string, []any and the other slices), tables ([][]any), maps (the key and the value columns)
+// and slices of structs (the columns of the fields), and returns the empty schema for the other data.
+func (s *Selector) Schema() Schema {
+	header := s.Header
+	if len(header) == 0 {
+		header = s.Options.Header
+	}
+	var schema Schema
+	column := func(j int) *ColumnType {
+		for len(schema.Columns) <= j {
+			c := ColumnType{}
+			if len(schema.Columns) < len(header) {
+				c.Name = header[len(schema.Columns)]
+			}
+			schema.Columns = append(schema.Columns, c)
+		}
+		return &schema.Columns[j]
+	}
+	table := func(rows [][]any) {
+		schema.Table = true
+		columns := len(header)
+		for _, row := range rows {
+			columns = max(columns, len(row))
+		}
+		if columns > 0 {
+			column(columns - 1)
+		}
+		for _, row := range rows {
+			for j := range columns {
+				if j >= len(row) {
+					// a short row has no value in the column
+					column(j).Nullable = true
+					continue
+				}
+				column(j).add(row[j])
+			}
+		}
+	}
+
+	switch data := s.Data.(type) {
+	case []string:
+		c := column(0)
+		c.Kind, c.Kinds = KindString, []Kind{KindString}
+		return schema
+	case []any:
+		c := column(0)
+		for _, v := range data {
+			c.add(v)
+		}
+		return schema
+	case [][]any:
+		table(data)
+		return schema
+	}
+	switch v := reflect.ValueOf(s.Data); {
+	case isStructSlice(v):
+		t, err := structTable(v)
+		if err != nil {
+			return Schema{}
+		}
+		if len(header) == 0 {
+			header = t.header
+		}
+		table(t.rows)
+	case v.Kind() == reflect.Slice:
+		c := column(0)
+		for i := range v.Len() {
+			c.add(v.Index(i).Interface())
+		}
+	case v.Kind() == reflect.Map:
+		keys := sortedKeys(v)
+		rows := make([][]any, len(keys))
+		for i, k := range keys {
+			rows[i] = []any{k.Interface(), v.MapIndex(k).Interface()}
+		}
+		table(rows)
+	}
+	return schema
+}
@@ -0,0 +1,130 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSelector_Schema(t *testing.T) {
+	name := "web"
+	var noName *string
+	s := &select5.Selector{
+		Header: []string{"NAME", "PORT", "LOAD", "UPTIME", "NOTE"},
+		Data: [][]any{
+			{&name, uint16(80), float32(0.5), time.Hour, "ok"},
+			{noName, uint16(443), float32(1.5), 2 * time.Hour, 3},
+			{"db", int8(5), float32(0.1), time.Minute},
+		},
+	}
+	schema := s.Schema()
+	if !schema.Table {
+		t.Fatal("expected a table schema")
+	}
+	want := []select5.ColumnType{
+		{Name: "NAME", Kind: select5.KindString, Kinds: []select5.Kind{select5.KindString}, Nullable: true, Pointer: true},
+		{Name: "PORT", Kind: select5.KindMixed, Kinds: []select5.Kind{select5.KindUint16, select5.KindInt8}},
+		{Name: "LOAD", Kind: select5.KindFloat32, Kinds: []select5.Kind{select5.KindFloat32}},
+		{Name: "UPTIME", Kind: select5.KindDuration, Kinds: []select5.Kind{select5.KindDuration}},
+		{Name: "NOTE", Kind: select5.KindMixed, Kinds: []select5.Kind{select5.KindString, select5.KindInt}, Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Columns, want) {
+		t.Fatalf("Schema() = %+v, want %+v", schema.Columns, want)
+	}
+	if !schema.Columns[1].Mixed() || schema.Columns[2].Mixed() {
+		t.Errorf("unexpected Mixed() of the columns %+v", schema.Columns)
+	}
+	if s.Type() != select5.IsTable|select5.IsAny {
+		t.Errorf("Type() = %#x, want the mixed table", s.Type())
+	}
+}
+
+func TestSelector_SchemaList(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		kind select5.Kind
+		typ  byte
+	}{
+		{"strings", []string{"a"}, select5.KindString, select5.IsList | select5.IsString},
+		{"empty strings", []string{}, select5.KindString, select5.IsList | select5.IsString},
+		{"ints", []any{1, 2}, select5.KindInt, select5.IsList | select5.IsInt},
+		{"int8 and int16", []any{int8(1), int16(2)}, select5.KindMixed, select5.IsList | select5.IsInt},
+		{"uint64", []any{uint64(1), nil}, select5.KindUint64, select5.IsList | select5.IsInt64},
+		{"times", []any{time.Now()}, select5.KindTime, select5.IsList | select5.IsString},
+		{"complex", []any{complex(1, 2)}, select5.KindComplex128, select5.IsList | select5.IsAny},
+		{"only nil", []any{nil}, select5.KindNone, select5.IsList},
+		{"typed ints", []int{1, 2}, select5.KindInt, select5.IsList | select5.IsInt},
+		{"durations", []time.Duration{time.Second}, select5.KindDuration, select5.IsList | select5.IsInt64},
+		{"empty float64s", []float64{}, select5.KindNone, select5.IsList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &select5.Selector{Data: tt.data}
+			schema := s.Schema()
+			if schema.Table || len(schema.Columns) != 1 {
+				t.Fatalf("expected a list schema of one column, got %+v", schema)
+			}
+			if got := schema.Columns[0].Kind; got != tt.kind {
+				t.Errorf("Kind = %s, want %s", got, tt.kind)
+			}
+			if got := s.Type(); got != tt.typ {
+				t.Errorf("Type() = %#x, want %#x", got, tt.typ)
+			}
+		})
+	}
+	if schema := (&select5.Selector{Data: 42}).Schema(); schema.Table || schema.Columns != nil {
+		t.Errorf("expected the empty schema for unsupported data, got %+v", schema)
+	}
+}
+
+func TestSelector_SchemaMap(t *testing.T) {
+	s := &select5.Selector{Data: map[string]int{"b": 2, "a": 1}}
+	schema := s.Schema()
+	want := []select5.ColumnType{
+		{Kind: select5.KindString, Kinds: []select5.Kind{select5.KindString}},
+		{Kind: select5.KindInt, Kinds: []select5.Kind{select5.KindInt}},
+	}
+	if !schema.Table || !reflect.DeepEqual(schema.Columns, want) {
+		t.Fatalf("Schema() = %+v, want the table of %+v", schema, want)
+	}
+	if got := s.Type(); got != select5.IsTable|select5.IsString|select5.IsInt|select5.IsAny {
+		t.Errorf("Type() = %#x, want the mixed table", got)
+	}
+
+	s.Header = []string{"KEY", "VALUE"}
+	if schema := s.Schema(); schema.Columns[0].Name != "KEY" || schema.Columns[1].Name != "VALUE" {
+		t.Errorf("expected the columns named by the header, got %+v", schema.Columns)
+	}
+}
+
+func TestSelector_SchemaStructs(t *testing.T) {
+	var data []*server
+	for i := range servers {
+		data = append(data, &servers[i])
+	}
+	want := []select5.ColumnType{
+		{Name: "NAME", Kind: select5.KindString, Kinds: []select5.Kind{select5.KindString}},
+		{Name: "ZONE", Kind: select5.KindString, Kinds: []select5.Kind{select5.KindString}},
+		{Name: "COST", Kind: select5.KindFloat64, Kinds: []select5.Kind{select5.KindFloat64}},
+	}
+	for _, d := range []any{servers, data} {
+		s := &select5.Selector{Data: d}
+		schema := s.Schema()
+		// the Note column is omitted, since it is empty in all the rows
+		if !schema.Table || !reflect.DeepEqual(schema.Columns, want) {
+			t.Fatalf("Schema() of %T = %+v, want the table of %+v", d, schema, want)
+		}
+		if got := s.Type(); got != select5.IsTable|select5.IsString|select5.IsFloat64|select5.IsAny {
+			t.Errorf("Type() of %T = %#x, want the mixed table", d, got)
+		}
+	}
+
+	type unsupported struct {
+		Ch chan int
+	}
+	if schema := (&select5.Selector{Data: []unsupported{{}}}).Schema(); schema.Table || schema.Columns != nil {
+		t.Errorf("expected the empty schema for unsupported fields, got %+v", schema)
+	}
+}
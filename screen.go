@@ -0,0 +1,123 @@
+package select5
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScreenMode is the way to draw the selection in the terminal
+type ScreenMode int
+
+// Screen modes of the selection
+const (
+	FullScreen   ScreenMode = iota // clears the screen and draws from the top-left corner (default)
+	InlineScreen                   // draws below the cursor with the lines it needs, and erases itself on exit
+	AltScreen                      // draws in the alternate screen, and the prior contents come back on exit
+)
+
+// summaryMark is the mark of the summary line left after the selection
+const summaryMark = "✔ picked: "
+
+// screenHeight returns the number of the lines available for the frame
+func (s *session) screenHeight() int {
+	_, height := terminalSize()
+	if s.opts.Screen == InlineScreen && s.opts.Height > 0 && s.opts.Height < height {
+		height = s.opts.Height
+	}
+	if s.hasPreview() {
+		if position, size := s.previewLayout(); position == PreviewBottom {
+			// the pane and its border
+			height -= size
+		}
+	}
+	return height
+}
+
+// screenWidth returns the number of the columns available for the frame, except the preview pane
+func (s *session) screenWidth() int {
+	width, _ := terminalSize()
+	if s.hasPreview() {
+		if position, size := s.previewLayout(); position == PreviewRight {
+			// the pane and the border " │ "
+			width -= size + 3
+		}
+	}
+	return width
+}
+
+// openScreen prepares the terminal for the screen mode
+func (s *session) openScreen() {
+	switch s.opts.Screen {
+	case InlineScreen:
+		// a wrapped line breaks the relative movement
+		fmt.Print(HideCursor + DisableAutoWrap)
+	case AltScreen:
+		fmt.Print(EnterAltScreen + ClearScreen + ResetCursor + HideCursor)
+	default:
+		fmt.Print(ClearScreen + ResetCursor + HideCursor)
+	}
+	s.drawn = 0
+}
+
+// closeScreen erases the selection and restores the terminal.
+// The summary line of the chosen items is left if the Summary option is set.
+func (s *session) closeScreen(chosen []int) {
+	var b strings.Builder
+	switch s.opts.Screen {
+	case InlineScreen:
+		if s.drawn > 1 {
+			fmt.Fprintf(&b, MoveUp, s.drawn-1)
+		}
+		b.WriteString("\r" + ClearScreenFromCursor + EnableAutoWrap + ShowCursor)
+	case AltScreen:
+		b.WriteString(ClearScreen + ShowCursor + ExitAltScreen)
+	default:
+		b.WriteString(ClearScreen + ResetCursor + ShowCursor)
+	}
+	if s.opts.Summary && len(chosen) > 0 {
+		var labels []string
+		for _, index := range chosen {
+			labels = append(labels, s.labels[index])
+		}
+		b.WriteString(summaryMark + strings.Join(labels, ", ") + "\r\n")
+	}
+	fmt.Print(b.String())
+}
+
+// draw writes the lines of the frame to the screen with the preview pane, and erases the rest of the previous frame
+func (s *session) draw(frame []string) {
+	// one line for each item, without wrapping
+	width := s.screenWidth()
+	for i, line := range frame {
+		frame[i] = truncateWidth(line, width, Ellipsis)
+	}
+	if s.hasPreview() {
+		frame = s.withPreview(frame)
+	}
+	var b strings.Builder
+	switch s.opts.Screen {
+	case InlineScreen:
+		// go back to the first line of the previous frame
+		if s.drawn > 1 {
+			fmt.Fprintf(&b, MoveUp, s.drawn-1)
+		}
+		b.WriteString("\r")
+		for i, line := range frame {
+			if i > 0 {
+				b.WriteString("\r\n")
+			}
+			b.WriteString(ClearLine)
+			b.WriteString(line)
+		}
+		s.drawn = len(frame)
+	default:
+		b.WriteString(ResetCursor)
+		for i, line := range frame {
+			fmt.Fprintf(&b, MoveTo, i+1, 1)
+			b.WriteString(ClearLine)
+			b.WriteString(line)
+		}
+	}
+	b.WriteString(ClearScreenFromCursor)
+	fmt.Print(b.String())
+}
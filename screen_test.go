@@ -0,0 +1,107 @@
+package select5_test
+
+import (
+	"bytes"
+	"github.com/g1eng/select5"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectStringWith_Screen(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     select5.SelectOptions
+		contains []string
+		excludes []string
+		suffix   string
+	}{
+		{
+			name:     "inline",
+			opts:     select5.SelectOptions{Screen: select5.InlineScreen, Summary: true},
+			contains: []string{select5.DisableAutoWrap, select5.EnableAutoWrap, "\r\n"},
+			excludes: []string{select5.ClearScreen, select5.ResetCursor, select5.EnterAltScreen},
+			suffix:   "✔ picked: medium\r\n",
+		},
+		{
+			name:     "inline without summary",
+			opts:     select5.SelectOptions{Screen: select5.InlineScreen, Height: 3},
+			excludes: []string{select5.ClearScreen, "picked"},
+			suffix:   select5.ShowCursor,
+		},
+		{
+			name:     "alternate screen",
+			opts:     select5.SelectOptions{Screen: select5.AltScreen},
+			contains: []string{select5.EnterAltScreen},
+			suffix:   select5.ExitAltScreen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+			outR, outW, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer outR.Close()
+
+			oldStdin, oldStdout := os.Stdin, os.Stdout
+			os.Stdin, os.Stdout = r, outW
+			defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+			outCh := make(chan string)
+			go func() {
+				var buf bytes.Buffer
+				io.Copy(&buf, outR)
+				outCh <- buf.String()
+			}()
+
+			resultCh := make(chan string)
+			go func() {
+				result, err := select5.SelectStringWith([]string{"small", "medium", "large"}, tt.opts)
+				if err != nil {
+					panic(err)
+				}
+				resultCh <- result
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte{0x0a})
+
+			select {
+			case result := <-resultCh:
+				if result != "medium" {
+					t.Fatalf("Expected 'medium' to be selected, got '%s'", result)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for selection")
+			}
+			outW.Close()
+			out := <-outCh
+
+			for _, s := range tt.contains {
+				if !strings.Contains(out, s) {
+					t.Errorf("expected %q in the output %q", s, out)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(out, s) {
+					t.Errorf("unexpected %q in the output %q", s, out)
+				}
+			}
+			if !strings.HasSuffix(out, tt.suffix) {
+				t.Errorf("expected the output to end with %q, got %q", tt.suffix, out)
+			}
+		})
+	}
+}
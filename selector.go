@@ -1,11 +1,9 @@
 package select5
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"github.com/olekukonko/tablewriter"
-	"golang.org/x/term"
-	"os"
+	"reflect"
 	"strings"
 )
 
@@ -28,8 +26,10 @@ const (
 
 // Selector represents a selectable dataset with an optional header
 type Selector struct {
-	Header []string // selection header
-	Data   any
+	Header  []string // selection header
+	Data    any
+	Multi   bool          // select multiple items with Space and 'a'
+	Options SelectOptions // options of the selection
 }
 
 // NewSelectorFrom creates a new Selector from a slice of any type
@@ -45,29 +45,16 @@ func NewSelectorFrom(p []any) *Selector {
 }
 
 // Type determines the type of data in the selector
-// Returns a byte value representing the data type(s).
+// Returns a byte value representing the data type(s), derived from the Schema.
+// Use Schema for the types of each column.
 func (s *Selector) Type() byte {
-
+	schema := s.Schema()
 	var elementType = IsList
-	switch s.Data.(type) {
-	case []string:
-		elementType |= IsString
-	case []any:
-		for _, r := range s.Data.([]any) {
-			elementType |= CheckPrimitive(r)
-		}
-	case [][]any:
-		for i, _ := range s.Data.([][]any) {
-			elementType |= IsTable
-			for j, _ := range s.Data.([][]any)[i] {
-				elementType |= CheckPrimitive(s.Data.([][]any)[i][j])
-				if elementType == IsAny|IsTable {
-					break
-				}
-			}
-		}
-	default:
-		return elementType //non-list
+	if schema.Table {
+		elementType = IsTable
+	}
+	for _, c := range schema.Columns {
+		elementType |= c.bits()
 	}
 	typeMask := IsAny & elementType
 	if typeMask&(typeMask-1) != 0 { // two or more types detected
@@ -77,164 +64,211 @@ func (s *Selector) Type() byte {
 }
 
 // RenderMenu draws the menu with the current selection (internal use)
+// prevIndex is kept for compatibility, as the whole menu is redrawn.
 func RenderMenu(list []string, selectedIndex int, prevIndex int) {
+	s := newSession(list)
+	if selectedIndex >= 0 && selectedIndex < len(s.view) {
+		s.cursor = selectedIndex
+	}
+	renderMenu(s)
+}
 
-	// Position cursor at the top
-	fmt.Print(ResetCursor)
-	if selectedIndex == prevIndex && selectedIndex == 0 {
-		for i, item := range list {
-			if i == 0 {
-				fmt.Printf("\033[%d;1H", i+1)
-				fmt.Print(ClearLine)
-				fmt.Print("> ")
-				fmt.Print(item)
-			} else {
-				fmt.Printf("\033[%d;1H", i+1)
-				fmt.Print(ClearLine)
-				fmt.Print("  ")
-				fmt.Print(item)
-			}
+// renderMenu draws the filtered items of the session in the screen with the cursor and the position indicator.
+// The prompt, the query (if the session is filterable) and the footer are shown around the items.
+func renderMenu(s *session) error {
+	cursor := s.cursorGlyph()
+	footer := lines(s.opts.Footer)
+	frame := lines(s.opts.Prompt)
+	if s.filterable {
+		frame = append(frame, "Filter: "+string(s.query))
+	}
+	s.scroll(s.screenHeight() - len(frame) - 1 - len(footer))
+	s.top = len(frame)
+	from, to := s.window()
+	for i := from; i < to; i++ {
+		index := s.view[i]
+		style := s.itemStyle(i, index)
+		line := blank(cursor)
+		if i == s.cursor {
+			line = cursor
 		}
-		return
+		line += s.marker(index) + s.highlight(s.labels[index], s.matches[index], style)
+		frame = append(frame, style.render(line, s.profile))
 	}
+	status := s.position()
+	if s.err != nil {
+		status += fmt.Sprintf("  (%s)", s.err)
+	}
+	frame = append(frame, status)
+	s.draw(append(frame, footer...))
+	return nil
+}
 
-	for i, item := range list {
-		if i == prevIndex {
-			fmt.Printf("\033[%d;1H", i+1)
-			fmt.Print(ClearLine)
-			fmt.Print("  ")
-			fmt.Print(item)
-		}
-		if i == selectedIndex {
-			fmt.Printf("\033[%d;1H", i+1)
-			fmt.Print(ClearLine)
-			fmt.Print("> ")
-			fmt.Print(item)
+// highlight decorates the characters at the rune positions with the matched style of the theme.
+// The style of the item is started again after each match.
+func (s *session) highlight(item string, positions []int, style Style) string {
+	match := s.theme().Matched.sgr(s.profile)
+	if len(positions) == 0 || match == "" {
+		return item
+	}
+	restart := resetStyle + style.sgr(s.profile)
+	var b strings.Builder
+	p := 0
+	for i, r := range []rune(item) {
+		if p < len(positions) && positions[p] == i {
+			b.WriteString(match)
+			b.WriteRune(r)
+			b.WriteString(restart)
+			p++
+		} else {
+			b.WriteRune(r)
 		}
 	}
+	return b.String()
 }
 
 // Select performs the selection based on the data type.
-// Returns the selected item or an error if selection is not supported
+// Data may be a list of strings, a table of primitives, or a slice of structs or struct pointers (shown as a table).
+// A slice of the other values supported by GetVP (like []any, []int or []float64) is shown as a list, and the original element is returned.
+// A map is shown as a table of the keys and the values sorted by the keys, and the KeyValue of the entry is returned.
+// It may also be a streaming source of strings or rows (a channel, iter.Seq or iter.Seq2 with an error),
+// which is shown while the items arrive.
+// Options configure the selection, and Header is used as Options.Header if it is set.
+// Returns the selected item (or the selected items if Multi is set) or an error if selection is not supported
 func (s *Selector) Select() (any, error) {
-	if s.Type()&IsTable == IsTable {
-		return SelectTableRow(s.Data.([][]any))
-	} else if s.Type()&IsAny == IsString {
-		return SelectString(s.Data.([]string))
-	} else {
-		return nil, fmt.Errorf("selection not supported for the type %d %T", s.Type(), s.Data)
+	return s.SelectContext(context.Background())
+}
+
+// SelectContext works like Select, and returns ctx.Err() if the context is done before the selection.
+func (s *Selector) SelectContext(ctx context.Context) (any, error) {
+	opts := s.Options
+	if len(s.Header) > 0 {
+		opts.Header = s.Header
+	}
+	switch data := s.Data.(type) {
+	case [][]any:
+		if s.Multi {
+			return SelectTableRowsContext(ctx, data, opts)
+		}
+		return SelectTableRowContext(ctx, data, opts)
+	case []string:
+		if s.Multi {
+			return SelectStringsContext(ctx, data, opts)
+		}
+		return SelectStringContext(ctx, data, opts)
+	}
+	switch v := reflect.ValueOf(s.Data); {
+	case isStructSlice(v):
+		return s.selectStructs(ctx, v, opts)
+	case v.Kind() == reflect.Slice:
+		return s.selectList(ctx, v, opts)
+	case v.Kind() == reflect.Map:
+		return s.selectMap(ctx, v, opts)
 	}
+
+	// the reading of a streaming source is stopped after the selection
+	done := make(chan struct{})
+	defer close(done)
+	if feed, isTable, ok := streamSource(s.Data, done); ok {
+		return s.selectStream(ctx, feed, isTable, opts)
+	}
+	return nil, fmt.Errorf("%w: selection of %T", ErrUnsupportedType, s.Data)
 }
 
 // SelectString presents a list of strings for selection and returns the selected string.
 // It displays an interactive cursor that can be moved with arrow keys.
+// Typing printable characters narrows the list with a fuzzy search, and BS/DEL edits the query.
 // Returns the selected string or an error if:
 // - the provided slice is empty
 // - the keyboard event channel closes
-// - the user quits (q or Ctrl+C)
+// - the user quits (ESC or Ctrl+C)
 func SelectString(list []string) (string, error) {
+	return SelectStringWith(list, SelectOptions{})
+}
+
+// SelectStringWith works like SelectString, configured with the options.
+func SelectStringWith(list []string, opts SelectOptions) (string, error) {
+	return SelectStringContext(context.Background(), list, opts)
+}
+
+// SelectStringContext works like SelectStringWith, and returns ctx.Err() if the context is done before the selection.
+func SelectStringContext(ctx context.Context, list []string, opts SelectOptions) (string, error) {
 	if len(list) == 0 {
-		return "", fmt.Errorf("zero length list provided")
+		return "", fmt.Errorf("SelectString: %w", ErrEmptyList)
 	}
 
-	var oldState *term.State
-	isTerm := term.IsTerminal(int(os.Stdin.Fd()))
-	if isTerm {
-		var err error
-		oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
-		if err != nil {
-			return "", err
-		}
-		defer term.Restore(int(os.Stdin.Fd()), oldState)
-		defer term.Restore(int(os.Stdout.Fd()), oldState)
+	indices, err := selectMenu(ctx, list, opts, false)
+	if err != nil || indices == nil {
+		return "", err
 	}
+	return list[indices[0]], nil
+}
 
-	fmt.Print(ClearScreen)
-	fmt.Print(HideCursor)
-
-	keyEvents, sigChan := CaptureKeyboardEvents()
-
-	selectedIndex := 0
-	prevIndex := 0
-
-	// Initial render of the menu
-	RenderMenu(list, selectedIndex, prevIndex)
-
-	for {
-		prevIndex = selectedIndex
-		select {
-		case key, ok := <-keyEvents:
-			if !ok {
-				return "", fmt.Errorf("keyboard event channel closed")
-			}
-
-			if key.Special != 0 {
-				switch key.Special {
-				case UP:
-					selectedIndex = (selectedIndex - 1 + len(list)) % len(list)
-					RenderMenu(list, selectedIndex, prevIndex)
-				case DOWN:
-					selectedIndex = (selectedIndex + 1) % len(list)
-					RenderMenu(list, selectedIndex, prevIndex)
-				case ENTER:
-					// Clear screen and show the selection
-					fmt.Print(ClearScreen)
-					fmt.Print(ResetCursor)
-					fmt.Print(ShowCursor)
-					return list[selectedIndex], nil
-				}
-			} else if key.Key == 'q' || (key.Ctrl && key.Key == 'c') {
-				// Quit on 'q' or Ctrl+C
-				fmt.Print(ClearScreen)
-				fmt.Print(ResetCursor)
-				fmt.Print('\n')
-				return "", nil
-			}
-
-		case <-sigChan:
-			fmt.Printf("\033[%d;1H", len(list)+1)
-			return "", nil
-		}
-	}
+// selectMenu presents the labels for selection with the fuzzy search.
+// It returns the indices of the chosen labels, or an error if the user quits.
+func selectMenu(ctx context.Context, labels []string, opts SelectOptions, multi bool) ([]int, error) {
+	return selectItems(ctx, labels, func(i int) any { return labels[i] }, opts, multi)
 }
 
-// RenderTable draws the table with a row cursor. (internal use)
-func RenderTable(list [][]any, selectedIndex int) error {
-	var buf bytes.Buffer
-	t := tablewriter.NewWriter(&buf)
-	if selectedIndex < 0 {
-		selectedIndex = 0
+// selectItems works like selectMenu for the items shown with the labels.
+// The item is given to the preview, and compared with the DefaultValue option.
+func selectItems(ctx context.Context, labels []string, item func(index int) any, opts SelectOptions, multi bool) ([]int, error) {
+	s := newSession(labels)
+	s.item = item
+	s.opts = opts
+	s.filterable = true
+	if multi {
+		s.multi = true
+		s.modal = true
+	} else {
+		s.typing = true
 	}
+	s.start(opts.defaultIndex(len(labels), item))
+	return s.run(ctx, renderMenu)
+}
 
-	for _, row := range list {
-		var newRow []string
-		for _, r := range row {
-			v, err := GetV(r)
-			if err != nil {
-				return err
-			}
-			newRow = append(newRow, v)
-		}
-		t.Append(newRow)
+// SelectStrings presents a list of strings for multiple selection and returns the marked strings.
+// Space marks or unmarks the item under the cursor, and 'a' marks or unmarks all the items.
+// '/' starts the fuzzy search, which is ended with Enter or ESC.
+// Enter returns the marked items in the original order, or the item under the cursor if no item is marked.
+// Returns an error if:
+// - the provided slice is empty
+// - the keyboard event channel closes
+// - the user quits (q, ESC or Ctrl+C)
+func SelectStrings(list []string) ([]string, error) {
+	return SelectStringsWith(list, SelectOptions{})
+}
+
+// SelectStringsWith works like SelectStrings, configured with the options.
+func SelectStringsWith(list []string, opts SelectOptions) ([]string, error) {
+	return SelectStringsContext(context.Background(), list, opts)
+}
+
+// SelectStringsContext works like SelectStringsWith, and returns ctx.Err() if the context is done before the selection.
+func SelectStringsContext(ctx context.Context, list []string, opts SelectOptions) ([]string, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("SelectStrings: %w", ErrEmptyList)
 	}
-	t.SetBorder(false)
-	t.Render()
 
-	data := buf.Bytes()
-	if len(data) == 0 {
-		return fmt.Errorf("no table data")
+	indices, err := selectMenu(ctx, list, opts, true)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	var res []string
+	for _, i := range indices {
+		res = append(res, list[i])
 	}
+	return res, nil
+}
 
-	tableRowStringSlices := strings.Split(string(data), "\n")
-	for i, row := range tableRowStringSlices {
-		fmt.Printf(MoveTo, i+1, 1)
-		if i == selectedIndex {
-			fmt.Printf("\x1b[01;07m%s\x1b[01;00m\n", row)
-		} else {
-			fmt.Println(row)
-		}
+// RenderTable draws the table with a row cursor. (internal use)
+func RenderTable(list [][]any, selectedIndex int) error {
+	t, _ := newTable(nil, list)
+	s := newSession(t.labels())
+	if selectedIndex >= 0 && selectedIndex < len(s.view) {
+		s.cursor = selectedIndex
 	}
-	return nil
+	return t.render(s)
 }
 
 // SelectTableRow presents a table of mixed data types for selection and returns the selected row.
@@ -244,73 +278,103 @@ func RenderTable(list [][]any, selectedIndex int) error {
 // - the keyboard event channel closes
 // - the user quits (q or Ctrl+C)
 func SelectTableRow(list [][]any) ([]any, error) {
+	return SelectTableRowWith(list, SelectOptions{})
+}
+
+// SelectTableRowWithHeader works like SelectTableRow, with the header pinned at the top of the table.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableRowWithHeader(header []string, list [][]any) ([]any, error) {
+	return SelectTableRowWith(list, SelectOptions{Header: header})
+}
+
+// SelectTableRowWith works like SelectTableRow, configured with the options.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableRowWith(list [][]any, opts SelectOptions) ([]any, error) {
+	return SelectTableRowContext(context.Background(), list, opts)
+}
+
+// SelectTableRowContext works like SelectTableRowWith, and returns ctx.Err() if the context is done before the selection.
+func SelectTableRowContext(ctx context.Context, list [][]any, opts SelectOptions) ([]any, error) {
 	if len(list) == 0 {
-		return nil, fmt.Errorf("zero length list provided")
+		return nil, fmt.Errorf("SelectTableRow: %w", ErrEmptyList)
 	}
-	var oldState *term.State
-	isTerm := term.IsTerminal(int(os.Stdin.Fd()))
-	if isTerm {
-		var err error
-		oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
-		if err != nil {
-			return nil, err
-		}
-		defer term.Restore(int(os.Stdin.Fd()), oldState)
-		defer term.Restore(int(os.Stdout.Fd()), oldState)
+	t, err := newTable(opts.Header, list)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Print(ClearScreen)
-	fmt.Print(ResetCursor)
-	fmt.Print(HideCursor)
-
-	keyEvents, sigChan := CaptureKeyboardEvents()
-
-	selectedIndex := 0
-
-	// Initial render of the menu
-	RenderTable(list, selectedIndex)
-
-	for {
-		select {
-		case key, ok := <-keyEvents:
-			if !ok {
-				return nil, fmt.Errorf("keyboard event channel closed")
-			}
-
-			if key.Special != 0 {
-				switch key.Special {
-				case UP:
-					selectedIndex = (selectedIndex - 1 + len(list)) % len(list)
-					// Clear and reposition cursor before redrawing
-					fmt.Print(ClearScreen)
-					fmt.Print(ResetCursor)
-					RenderTable(list, selectedIndex)
-				case DOWN:
-					selectedIndex = (selectedIndex + 1) % len(list)
-					// Clear and reposition cursor before redrawing
-					fmt.Print(ClearScreen)
-					fmt.Print(ResetCursor)
-					RenderTable(list, selectedIndex)
-				case ENTER:
-					// Clear screen and show the selection
-					fmt.Print(ClearScreen)
-					fmt.Print(ResetCursor)
-					fmt.Print(ShowCursor)
-					return list[selectedIndex], nil
-				}
-			} else if key.Key == 'q' || (key.Ctrl && key.Key == 'c') {
-				// Quit on q or Ctrl+C
-				fmt.Print(ClearScreen)
-				fmt.Print(ResetCursor)
-				fmt.Print(ShowCursor)
-				return nil, nil
-			}
-
-		case <-sigChan:
-			fmt.Print(ClearScreen)
-			fmt.Print(ResetCursor)
-			fmt.Print(ShowCursor)
-			return nil, nil
-		}
+	indices, err := selectTable(ctx, t, opts, false)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	return list[indices[0]], nil
+}
+
+// selectTable presents the table for selection.
+// '/' starts the filter expression, which is ended with Enter or ESC.
+// It returns the indices of the chosen rows, or an error if the user quits.
+func selectTable(ctx context.Context, t *table, opts SelectOptions, multi bool) ([]int, error) {
+	s := newSession(t.labels())
+	s.opts = opts
+	s.multi = multi
+	s.filterable = true
+	s.modal = true
+	s.handleKey = t.handleKey
+	if t.column >= 0 {
+		s.handleSpecial = t.handleSpecial
+	}
+	if t.editable {
+		s.handleKey = t.handleEditKey
+		s.handleSpecial = t.handleEditSpecial
+	}
+	s.arrange = t.sortView
+	s.search = t.search
+	s.item = t.item
+	s.filter()
+	s.start(opts.defaultIndex(len(t.rows), t.item))
+	return s.run(ctx, t.render)
+}
+
+// SelectTableRows presents a table of mixed data types for multiple selection and returns the marked rows.
+// Space marks or unmarks the row under the cursor, and 'a' marks or unmarks all the rows.
+// Enter returns the marked rows in the original order, or the row under the cursor if no row is marked.
+// Returns an error if:
+// - the provided slice is empty
+// - the keyboard event channel closes
+// - the user quits (q or Ctrl+C)
+func SelectTableRows(list [][]any) ([][]any, error) {
+	return SelectTableRowsWith(list, SelectOptions{})
+}
+
+// SelectTableRowsWithHeader works like SelectTableRows, with the header pinned at the top of the table.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableRowsWithHeader(header []string, list [][]any) ([][]any, error) {
+	return SelectTableRowsWith(list, SelectOptions{Header: header})
+}
+
+// SelectTableRowsWith works like SelectTableRows, configured with the options.
+// Returns an error if the header has a different number of columns from a row.
+func SelectTableRowsWith(list [][]any, opts SelectOptions) ([][]any, error) {
+	return SelectTableRowsContext(context.Background(), list, opts)
+}
+
+// SelectTableRowsContext works like SelectTableRowsWith, and returns ctx.Err() if the context is done before the selection.
+func SelectTableRowsContext(ctx context.Context, list [][]any, opts SelectOptions) ([][]any, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("SelectTableRows: %w", ErrEmptyList)
+	}
+	t, err := newTable(opts.Header, list)
+	if err != nil {
+		return nil, err
+	}
+
+	indices, err := selectTable(ctx, t, opts, true)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	var res [][]any
+	for _, i := range indices {
+		res = append(res, list[i])
 	}
+	return res, nil
 }
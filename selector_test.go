@@ -1,8 +1,10 @@
 package select5_test
 
 import (
+	"fmt"
 	"github.com/g1eng/select5"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -64,6 +66,106 @@ func TestSelectString(t *testing.T) {
 	}
 }
 
+func TestSelectString_Filter(t *testing.T) {
+	options := []string{"dev-cluster", "prod-cluster", "staging", "prod-sandbox"}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan string)
+	go func() {
+		result, err := select5.SelectString(options)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Type "psx", fix it to "ps" with backspace, then move to the second match.
+	// "prod-sandbox" is ranked first as "s" matches at the word boundary.
+	w.Write([]byte("psx"))
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x7f}) // DEL
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case result := <-resultCh:
+		if result != "prod-cluster" {
+			t.Fatalf("Expected 'prod-cluster' to be selected, got '%s'", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectString_Scroll(t *testing.T) {
+	var options []string
+	for i := 0; i < 200; i++ {
+		options = append(options, fmt.Sprintf("item-%03d", i))
+	}
+	tests := []struct {
+		name string
+		keys [][]byte
+		want string
+	}{
+		{"end and up", [][]byte{{0x1b, '[', 'F'}, {0x1b, '[', 'A'}}, "item-198"},
+		{"page down stops at the end", [][]byte{{0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}, {0x1b, '[', '6', '~'}}, "item-199"},
+		{"home and page up", [][]byte{{0x1b, '[', 'B'}, {0x1b, '[', 'H'}, {0x1b, '[', '5', '~'}}, "item-000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+
+			resultCh := make(chan string)
+			go func() {
+				result, err := select5.SelectString(options)
+				if err != nil {
+					panic(err)
+				}
+				resultCh <- result
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			for _, key := range tt.keys {
+				w.Write(key)
+				time.Sleep(20 * time.Millisecond)
+			}
+			w.Write([]byte{0x0a}) // ENTER key
+
+			select {
+			case result := <-resultCh:
+				if result != tt.want {
+					t.Fatalf("Expected '%s' to be selected, got '%s'", tt.want, result)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for selection")
+			}
+		})
+	}
+}
+
 func TestSelectStringWithBlankList(t *testing.T) {
 	_, err := select5.SelectString([]string{})
 	if err == nil {
@@ -124,6 +226,68 @@ func TestSelectTableRow(t *testing.T) {
 	}
 }
 
+func TestSelectTableRowWithHeader(t *testing.T) {
+	header := []string{"ID", "Name", "Mail", "Active"}
+	rows := [][]any{
+		{1, "Alice", "alice@example.com", false},
+		{2, "Bob", "bob@example.com", true},
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan []any)
+	go func() {
+		result, err := select5.SelectTableRowWithHeader(header, rows)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// The header is not selectable, so UP wraps around to the last row
+	w.Write([]byte{0x1b, '[', 'A'}) // UP arrow
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case result := <-resultCh:
+		if !rowsEqual(result, rows[1]) {
+			t.Fatalf("Expected row %v to be selected, got %v", rows[1], result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectTableRowWithHeader_WidthMismatch(t *testing.T) {
+	rows := [][]any{
+		{1, "Alice", "alice@example.com"},
+		{2, "Bob", "bob@example.com", true},
+	}
+	_, err := select5.SelectTableRowWithHeader([]string{"ID", "Name", "Mail"}, rows)
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if !strings.Contains(err.Error(), "row 1 has 4 columns") {
+		t.Errorf("Expected the error to tell the mismatched row, got %v", err)
+	}
+	_, err = select5.SelectTableRowsWithHeader([]string{"ID"}, rows)
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
+
 func TestSelectTableRowWithEmptyTable(t *testing.T) {
 	_, err := select5.SelectTableRow([][]any{})
 	if err == nil {
@@ -131,6 +295,155 @@ func TestSelectTableRowWithEmptyTable(t *testing.T) {
 	}
 }
 
+func TestSelectStrings(t *testing.T) {
+	options := []string{"default", "kube-system", "monitoring", "staging"}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan []string)
+	go func() {
+		result, err := select5.SelectStrings(options)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Mark the last item found with the filter, then the first one
+	w.Write([]byte{'/'})
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte("stag"))
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key ends the filter
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{' '})
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{0x1b}) // ESC clears the filter
+	time.Sleep(100 * time.Millisecond)
+	w.Write([]byte{' '})
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case result := <-resultCh:
+		if len(result) != 2 || result[0] != "default" || result[1] != "staging" {
+			t.Fatalf("Expected [default staging] to be selected, got %v", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelectTableRows(t *testing.T) {
+	rows := [][]any{
+		{1, "Alice", "alice@example.com", false},
+		{2, "Bob", "bob@example.com", true},
+		{3, "Charlie", "charlie@example.com", true},
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan [][]any)
+	go func() {
+		result, err := select5.SelectTableRows(rows)
+		if err != nil {
+			panic(err)
+		}
+		resultCh <- result
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Mark all the rows, then unmark the second one
+	w.Write([]byte{'a'})
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{' '})
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case result := <-resultCh:
+		if len(result) != 2 || !rowsEqual(result[0], rows[0]) || !rowsEqual(result[1], rows[2]) {
+			t.Fatalf("Expected rows 1 and 3 to be selected, got %v", result)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
+func TestSelector_Select_Multi(t *testing.T) {
+	s := []string{"AGI", "BMI", "CES"}
+	list := select5.Selector{
+		Data:  s,
+		Multi: true,
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	resultCh := make(chan any)
+	errCh := make(chan error)
+	go func() {
+		res, err := list.Select()
+		if err != nil {
+			errCh <- err
+		}
+		resultCh <- res
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Without any mark, the item under the cursor is returned
+	w.Write([]byte{0x1b, '[', 'B'}) // DOWN arrow
+	time.Sleep(50 * time.Millisecond)
+	w.Write([]byte{0x0a}) // ENTER key
+
+	select {
+	case result := <-resultCh:
+		res, ok := result.([]string)
+		if !ok {
+			t.Fatalf("Expected result to be []string, got %T", result)
+		}
+		if len(res) != 1 || res[0] != s[1] {
+			t.Fatalf("Expected [%v], got %v", s[1], res)
+		}
+	case e := <-errCh:
+		t.Fatalf("Expected error channel to be nil, got %v", e)
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+}
+
 func TestSelector_Select_String(t *testing.T) {
 	s := []string{"AGI", "BMI", "CES", "DEI", "ERR"}
 	list := select5.Selector{
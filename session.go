@@ -0,0 +1,406 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/term"
+	"os"
+	"sort"
+	"time"
+)
+
+// session keeps the state of an interactive selection (internal use)
+type session struct {
+	labels  []string      // searchable text of each item
+	query   []rune        // incremental filter query
+	view    []int         // indices of the items shown, in the display order
+	matches map[int][]int // matched rune positions of the shown items
+	cursor  int           // cursor position in the view
+	opts    SelectOptions // options of the selection
+	initial int           // original index of the default item, accepted on the idle timeout, or -1 for none
+	offset  int           // view position of the first item on the screen
+	page    int           // number of items on the screen
+
+	filterable bool         // whether the query can be edited
+	typing     bool         // whether printable keys are put in the query
+	modal      bool         // whether typing is started with '/' and ended with ENTER or ESC
+	multi      bool         // whether two or more items can be marked
+	marked     map[int]bool // marked items by the original index
+
+	handleKey     func(s *session, key KeyEvent) bool // additional key bindings, which return true if the key is handled
+	handleSpecial func(s *session, key KeyEvent) bool // additional bindings of the special keys before the defaults
+	finish        bool                                // set by the key bindings to end the session with the chosen items
+	arrange       func(view []int)                    // reorders the view after filtering
+	search        func(query string) ([]int, error)   // filters the items instead of the fuzzy search
+	err           error                               // error of the search, shown in the status line
+
+	feed    <-chan streamItem // items from a streaming source, or nil if the source is exhausted
+	add     func(v any) error // adds an item from the feed and its label
+	loadErr error             // error of the streaming source, shown in the status line
+	frame   int               // frame of the loading indicator
+
+	drawn     int          // number of the lines drawn in the inline mode
+	top       int          // line of the frame where the first item of the page is drawn
+	lastClick time.Time    // time of the last click on the item under the cursor, for a double click
+	profile   colorProfile // colors supported by the terminal
+
+	item            func(index int) any // original item of the index for the preview
+	previewRequests chan previewRequest // requests of the preview
+	previewIndex    int                 // original index of the item in the preview pane
+	previewText     string              // text of the preview pane
+}
+
+// newSession creates a session showing all the items
+func newSession(labels []string) *session {
+	s := &session{
+		labels:  labels,
+		marked:  map[int]bool{},
+		profile: detectColorProfile(),
+	}
+	s.item = func(index int) any { return s.labels[index] }
+	s.filter()
+	return s
+}
+
+// filter narrows the view to the items matching the query
+func (s *session) filter() {
+	if s.search != nil {
+		s.view, s.err = s.search(string(s.query))
+		s.matches = nil
+	} else {
+		s.view, s.matches = fuzzyFilter(string(s.query), s.labels)
+	}
+	if s.arrange != nil {
+		s.arrange(s.view)
+	}
+	s.cursor = 0
+	s.offset = 0
+}
+
+// rearrange reorders the view, keeping the cursor on the same item
+func (s *session) rearrange() {
+	index := s.selected()
+	s.filter()
+	s.moveTo(index)
+}
+
+// start places the cursor on the default item of the original index
+func (s *session) start(index int) {
+	s.initial = index
+	s.moveTo(index)
+}
+
+// moveTo moves the cursor to the item of the original index, if it is shown
+func (s *session) moveTo(index int) {
+	for i, v := range s.view {
+		if v == index {
+			s.cursor = i
+			return
+		}
+	}
+}
+
+// typeRune appends a character to the query
+func (s *session) typeRune(r rune) {
+	s.query = append(s.query, r)
+	s.filter()
+}
+
+// backspace removes the last character of the query
+func (s *session) backspace() {
+	if len(s.query) == 0 {
+		return
+	}
+	s.query = s.query[:len(s.query)-1]
+	s.filter()
+}
+
+// clearQuery resets the query and shows all the items
+func (s *session) clearQuery() {
+	s.query = nil
+	s.filter()
+}
+
+// move moves the cursor by delta, wrapping around the view unless the NoWrap option is set
+func (s *session) move(delta int) {
+	if len(s.view) == 0 {
+		return
+	}
+	if s.opts.NoWrap {
+		s.jump(delta)
+		return
+	}
+	s.cursor = ((s.cursor+delta)%len(s.view) + len(s.view)) % len(s.view)
+}
+
+// selected returns the original index of the item under the cursor, or -1 if no item is shown
+func (s *session) selected() int {
+	if len(s.view) == 0 {
+		return -1
+	}
+	return s.view[s.cursor]
+}
+
+// disabled returns true if the item of the original index cannot be chosen
+func (s *session) disabled(index int) bool {
+	for _, i := range s.opts.Disabled {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle marks or unmarks the item under the cursor
+func (s *session) toggle() {
+	if index := s.selected(); index >= 0 && !s.disabled(index) {
+		s.marked[index] = !s.marked[index]
+	}
+}
+
+// toggleAll unmarks all the shown items if all of them are marked, otherwise marks all of them
+func (s *session) toggleAll() {
+	all := true
+	for _, index := range s.view {
+		if !s.marked[index] && !s.disabled(index) {
+			all = false
+			break
+		}
+	}
+	for _, index := range s.view {
+		if !s.disabled(index) {
+			s.marked[index] = !all
+		}
+	}
+}
+
+// anyMarked returns true if one or more items are marked
+func (s *session) anyMarked() bool {
+	for _, ok := range s.marked {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// marker returns the indicator of a marked or unmarked item for multiple selection
+func (s *session) marker(index int) string {
+	if !s.multi {
+		return ""
+	}
+	if s.marked[index] {
+		return "[x] "
+	}
+	return "[ ] "
+}
+
+// chosen returns the original indices of the marked items in the original order.
+// If no item is marked, the item under the cursor is chosen.
+func (s *session) chosen() []int {
+	var indices []int
+	if s.multi {
+		for index, ok := range s.marked {
+			if ok {
+				indices = append(indices, index)
+			}
+		}
+		sort.Ints(indices)
+	}
+	if len(indices) == 0 {
+		indices = []int{s.selected()}
+	}
+	return indices
+}
+
+// run draws the session with render and handles the key events until the user chooses items.
+// It returns the original indices of the chosen items, or ErrCanceled (ErrInterrupted for Ctrl+C and signals) if the user quits.
+// If the context is done, it returns ctx.Err(), and if no key is pressed for the IdleTimeout option,
+// the marked items are chosen, or the default item if no item is marked.
+// An error of render (like a cell value which cannot be shown) ends the session with the error.
+func (s *session) run(ctx context.Context, render func(*session) error) ([]int, error) {
+	var oldState *term.State
+	isTerm := term.IsTerminal(int(os.Stdin.Fd()))
+	if isTerm {
+		var err error
+		oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotTerminal, err)
+		}
+		defer term.Restore(int(os.Stdin.Fd()), oldState)
+		defer term.Restore(int(os.Stdout.Fd()), oldState)
+	}
+
+	if s.opts.Mouse {
+		fmt.Print(EnableMouse)
+		defer fmt.Print(DisableMouse)
+	}
+	s.openScreen()
+	indices, err := s.loop(ctx, render)
+	s.closeScreen(indices)
+	return indices, err
+}
+
+// loop handles the key events and the other events of the session, until the user chooses items or quits
+func (s *session) loop(ctx context.Context, render func(*session) error) ([]int, error) {
+	done := make(chan struct{})
+	defer close(done)
+	keyEvents, sigChan := captureKeyboardEvents(os.Stdin, done, false)
+
+	var timer *time.Timer
+	var idle <-chan time.Time
+	if s.opts.IdleTimeout > 0 {
+		timer = time.NewTimer(s.opts.IdleTimeout)
+		defer timer.Stop()
+		idle = timer.C
+	}
+
+	var previews <-chan previewUpdate
+	if s.hasPreview() {
+		previews = s.startPreview(done)
+		draw := render
+		render = func(s *session) error {
+			s.requestPreview()
+			return draw(s)
+		}
+	}
+
+	var loading <-chan time.Time
+	if s.feed != nil {
+		ticker := time.NewTicker(loadingInterval)
+		defer ticker.Stop()
+		loading = ticker.C
+	}
+
+	// Initial render
+	if err := render(s); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case key, ok := <-keyEvents:
+			if !ok {
+				return nil, fmt.Errorf("%w: keyboard event channel closed", ErrNotTerminal)
+			}
+			if timer != nil {
+				timer.Reset(s.opts.IdleTimeout)
+			}
+			if key.Special == MOUSE {
+				if !s.mouse(key.Mouse) {
+					if err := render(s); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				// a double click accepts the item like ENTER
+				key = KeyEvent{Key: ENTER, Code: ENTER, Special: ENTER}
+			}
+
+			switch {
+			case key.Special != 0 && s.handleSpecial != nil && s.handleSpecial(s, key):
+				if s.finish {
+					return s.chosen(), nil
+				}
+			case key.Special == UP:
+				s.move(-1)
+			case key.Special == DOWN:
+				s.move(1)
+			case key.Special == PAGEUP:
+				s.jump(-s.pageSize())
+			case key.Special == PAGEDOWN:
+				s.jump(s.pageSize())
+			case key.Special == HOME:
+				s.jump(-len(s.view))
+			case key.Special == END:
+				s.jump(len(s.view))
+			case key.Special == BS || key.Special == DEL:
+				if !s.typing {
+					continue
+				}
+				s.backspace()
+			case key.Special == ENTER && s.typing && s.modal:
+				s.typing = false
+			case key.Special == ENTER:
+				if index := s.selected(); index < 0 || (s.disabled(index) && !s.anyMarked()) {
+					continue
+				}
+				return s.chosen(), nil
+			case key.Special == ESC && s.typing && s.modal:
+				s.typing = false
+				s.clearQuery()
+			case key.Special == ESC && len(s.query) > 0:
+				s.clearQuery()
+			case key.Ctrl && key.Key == CtrlC:
+				return nil, fmt.Errorf("%w by Ctrl+C", ErrInterrupted)
+			case key.Special == ESC:
+				return nil, fmt.Errorf("%w by ESC", ErrCanceled)
+			case !key.IsPrintable():
+				continue
+			case s.typing:
+				s.typeRune(key.Key)
+			case s.handleKey != nil && s.handleKey(s, key):
+				if s.finish {
+					return s.chosen(), nil
+				}
+			case s.multi && key.Key == ' ':
+				s.toggle()
+			case s.multi && key.Key == 'a':
+				s.toggleAll()
+			case s.filterable && key.Key == '/':
+				s.typing = true
+			case key.Key == 'q':
+				return nil, fmt.Errorf("%w by q", ErrCanceled)
+			default:
+				continue
+			}
+			if err := render(s); err != nil {
+				return nil, err
+			}
+
+		case sig := <-sigChan:
+			return nil, fmt.Errorf("%w by %s", ErrInterrupted, sig)
+
+		case <-idle:
+			if s.multi && s.anyMarked() {
+				return s.chosen(), nil
+			}
+			// a disabled default item or no default item is not accepted, and the session waits for the user
+			if s.initial < 0 || s.initial >= len(s.labels) || s.disabled(s.initial) {
+				continue
+			}
+			return []int{s.initial}, nil
+
+		case item, ok := <-s.feed:
+			s.load(item, ok)
+			if s.feed == nil && len(s.labels) == 0 {
+				if s.loadErr != nil {
+					return nil, s.loadErr
+				}
+				return nil, fmt.Errorf("Select: %w", ErrEmptyList)
+			}
+			if err := render(s); err != nil {
+				return nil, err
+			}
+
+		case u := <-previews:
+			if s.updatePreview(u) {
+				if err := render(s); err != nil {
+					return nil, err
+				}
+			}
+
+		case <-loading:
+			if s.feed == nil {
+				loading = nil
+			}
+			s.frame++
+			if err := render(s); err != nil {
+				return nil, err
+			}
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
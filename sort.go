@@ -0,0 +1,185 @@
+package select5
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode"
+)
+
+// Kinds of cell values in the sort order
+const (
+	sortNil = iota
+	sortBool
+	sortNumber
+	sortString
+	sortOther
+)
+
+// sortKey is a cell value classified with CheckPrimitive for sorting (internal use)
+type sortKey struct {
+	kind  int
+	isInt bool
+	i     int64
+	f     float64
+	b     bool
+	s     string
+}
+
+// newSortKey classifies the cell value, dereferencing pointers
+func newSortKey(v any) sortKey {
+	t := CheckPrimitive(v)
+	r := reflect.ValueOf(v)
+	if v == nil || (r.Kind() == reflect.Pointer && r.IsNil()) {
+		return sortKey{kind: sortNil}
+	}
+	if t == IsAny {
+		s, _ := GetVP(v)
+		return sortKey{kind: sortOther, s: s}
+	}
+	if t&IsPointer == IsPointer {
+		r = r.Elem()
+	}
+	switch t &^ IsPointer {
+	case IsInt, IsInt64, IsFloat32, IsFloat64:
+		return numberKey(r)
+	case IsBool:
+		return sortKey{kind: sortBool, b: r.Bool()}
+	default:
+		s, _ := GetVP(v)
+		return sortKey{kind: sortString, s: s}
+	}
+}
+
+// numberKey returns the sort key of an int, an unsigned int, a float or a json.Number
+func numberKey(r reflect.Value) sortKey {
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sortKey{kind: sortNumber, isInt: true, i: r.Int(), f: float64(r.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := r.Uint(); u <= math.MaxInt64 {
+			return sortKey{kind: sortNumber, isInt: true, i: int64(u), f: float64(u)}
+		}
+		return sortKey{kind: sortNumber, f: float64(r.Uint())}
+	case reflect.String: // json.Number
+		if i, err := strconv.ParseInt(r.String(), 10, 64); err == nil {
+			return sortKey{kind: sortNumber, isInt: true, i: i, f: float64(i)}
+		}
+		f, _ := strconv.ParseFloat(r.String(), 64)
+		return sortKey{kind: sortNumber, f: f}
+	}
+	return sortKey{kind: sortNumber, f: r.Float()}
+}
+
+// compareCells compares two cell values by the type detected with CheckPrimitive.
+// Numbers are compared numerically, false is ordered before true, and strings are compared with collate.
+// Values of different kinds are ordered as nil, bool, number, string and others.
+func compareCells(a, b any) int {
+	ka, kb := newSortKey(a), newSortKey(b)
+	if ka.kind != kb.kind {
+		return cmp.Compare(ka.kind, kb.kind)
+	}
+	switch ka.kind {
+	case sortNumber:
+		if ka.isInt && kb.isInt {
+			return cmp.Compare(ka.i, kb.i)
+		}
+		return cmp.Compare(ka.f, kb.f)
+	case sortBool:
+		if ka.b == kb.b {
+			return 0
+		} else if kb.b {
+			return -1
+		}
+		return 1
+	case sortString, sortOther:
+		return collate(ka.s, kb.s)
+	}
+	return 0
+}
+
+// collate compares two strings in the natural order for humans:
+// letters are compared case-insensitively, and digit sequences are compared by their numeric values ("web-9" < "web-10").
+// The strings equal in this order are compared bytewise.
+func collate(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si, sj := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			if c := compareDigits(ra[si:i], rb[sj:j]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := cmp.Compare(unicode.ToLower(ra[i]), unicode.ToLower(rb[j])); c != 0 {
+			return c
+		}
+		i++
+		j++
+	}
+	if c := cmp.Compare(len(ra)-i, len(rb)-j); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
+
+// compareDigits compares two digit sequences by their numeric values
+func compareDigits(a, b []rune) int {
+	for len(a) > 1 && a[0] == '0' {
+		a = a[1:]
+	}
+	for len(b) > 1 && b[0] == '0' {
+		b = b[1:]
+	}
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(string(a), string(b))
+}
+
+// sortView sorts the view by the sort column of the table, keeping the order of the equal rows
+func (t *table) sortView(view []int) {
+	if t.sortColumn < 0 {
+		return
+	}
+	cell := func(index int) any {
+		if t.sortColumn < len(t.rows[index]) {
+			return t.rows[index][t.sortColumn]
+		}
+		return nil
+	}
+	sort.SliceStable(view, func(a, b int) bool {
+		c := compareCells(cell(view[a]), cell(view[b]))
+		if t.sortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// nextSortColumn sorts the table by the next column in the ascending order.
+// After the last column, the original order is restored.
+func (t *table) nextSortColumn() {
+	t.sortColumn++
+	if t.sortColumn >= t.columns() {
+		t.sortColumn = -1
+	}
+	t.sortDesc = false
+}
+
+// sortMarker returns the marker of the sort order
+func (t *table) sortMarker() string {
+	if t.sortDesc {
+		return "▼"
+	}
+	return "▲"
+}
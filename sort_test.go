@@ -0,0 +1,75 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSelectTableRow_Sort(t *testing.T) {
+	one, two := 1, 2
+	rows := [][]any{
+		{"web-10", 10, 2.5, true, &two},
+		{"Web-9", 9, 10.0, false, (*int)(nil)},
+		{"db-1", 100, -1.0, true, &one},
+	}
+	tests := []struct {
+		name string
+		keys string
+		want int
+	}{
+		{"original order", "", 0},
+		{"strings in the natural order", "s", 2},
+		{"strings in the descending order", "sr", 0},
+		{"ints numerically", "ss", 1},
+		{"ints in the descending order", "ssr", 2},
+		{"floats numerically", "sss", 2},
+		{"bools", "ssss", 1},
+		{"pointers with nil first", "sssss", 1},
+		{"pointers in the descending order", "sssssr", 0},
+		{"back to the original order", "ssssss", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+
+			resultCh := make(chan []any)
+			go func() {
+				result, err := select5.SelectTableRowWithHeader([]string{"NAME", "CPU", "COST", "UP", "REF"}, rows)
+				if err != nil {
+					panic(err)
+				}
+				resultCh <- result
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			for _, key := range []byte(tt.keys) {
+				w.Write([]byte{key})
+				time.Sleep(20 * time.Millisecond)
+			}
+			// The cursor follows the row under it while sorting, so go back to the first row
+			w.Write([]byte{0x1b, '[', 'H'}) // HOME key
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte{0x0a}) // ENTER key
+
+			select {
+			case result := <-resultCh:
+				if !rowsEqual(result, rows[tt.want]) {
+					t.Fatalf("Expected row %v to be selected, got %v", rows[tt.want], result)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("Test timed out waiting for selection")
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Loading indicator of a streaming source
+const loadingInterval = 100 * time.Millisecond
+
+var loadingFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// streamItem is an item delivered from a streaming source, or the error which stops the source
+type streamItem struct {
+	value any // string or []any
+	err   error
+}
+
+// streamSource starts reading the streaming source in the data, which may be
+// <-chan string, <-chan []any, iter.Seq[string], iter.Seq[[]any], iter.Seq2[string, error] or iter.Seq2[[]any, error].
+// It returns the channel of the items, which is closed when the source is exhausted,
+// and whether the items are table rows. The reading is stopped when done is closed.
+// ok is false if the data is not a streaming source.
+func streamSource(data any, done <-chan struct{}) (feed <-chan streamItem, isTable bool, ok bool) {
+	switch d := data.(type) {
+	case <-chan string:
+		return readChan(d, done), false, true
+	case chan string:
+		return readChan((<-chan string)(d), done), false, true
+	case <-chan []any:
+		return readChan(d, done), true, true
+	case chan []any:
+		return readChan((<-chan []any)(d), done), true, true
+	case iter.Seq[string]:
+		return readSeq2(withNoError(d), done), false, true
+	case func(func(string) bool):
+		return readSeq2(withNoError(iter.Seq[string](d)), done), false, true
+	case iter.Seq[[]any]:
+		return readSeq2(withNoError(d), done), true, true
+	case func(func([]any) bool):
+		return readSeq2(withNoError(iter.Seq[[]any](d)), done), true, true
+	case iter.Seq2[string, error]:
+		return readSeq2(d, done), false, true
+	case func(func(string, error) bool):
+		return readSeq2(iter.Seq2[string, error](d), done), false, true
+	case iter.Seq2[[]any, error]:
+		return readSeq2(d, done), true, true
+	case func(func([]any, error) bool):
+		return readSeq2(iter.Seq2[[]any, error](d), done), true, true
+	}
+	return nil, false, false
+}
+
+// readChan forwards the values from the channel until it is closed or done is closed
+func readChan[T any](ch <-chan T, done <-chan struct{}) <-chan streamItem {
+	feed := make(chan streamItem, 256)
+	go func() {
+		defer close(feed)
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case feed <- streamItem{value: v}:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return feed
+}
+
+// readSeq2 forwards the values from the sequence until it ends with or without an error, or done is closed
+func readSeq2[T any](seq iter.Seq2[T, error], done <-chan struct{}) <-chan streamItem {
+	feed := make(chan streamItem, 256)
+	go func() {
+		defer close(feed)
+		for v, err := range seq {
+			item := streamItem{value: v, err: err}
+			select {
+			case feed <- item:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return feed
+}
+
+// withNoError converts the sequence to the sequence of the values without errors
+func withNoError[T any](seq iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// selectStream presents the items from the streaming source for selection while they arrive.
+// It returns the selected string or row (or the selected items if Multi is set),
+// or ErrEmptyList if the source is exhausted without any item.
+func (s *Selector) selectStream(ctx context.Context, feed <-chan streamItem, isTable bool, opts SelectOptions) (any, error) {
+	var (
+		list []string
+		t    *table
+		sess *session
+	)
+	if isTable {
+		t, _ = newTable(opts.Header, nil)
+		sess = newSession(nil)
+		sess.filterable = true
+		sess.modal = true
+		sess.handleKey = t.handleKey
+		sess.arrange = t.sortView
+		sess.search = t.search
+		sess.item = t.item
+		sess.add = func(v any) error {
+			row := v.([]any)
+			if len(t.header) > 0 && len(row) != len(t.header) {
+				return fmt.Errorf("header has %d columns, but row %d has %d columns", len(t.header), len(t.rows), len(row))
+			}
+			t.rows = append(t.rows, row)
+			sess.labels = append(sess.labels, t.rowLabel(row))
+			return nil
+		}
+	} else {
+		sess = newSession(nil)
+		sess.filterable = true
+		if s.Multi {
+			sess.modal = true
+		} else {
+			sess.typing = true
+		}
+		sess.add = func(v any) error {
+			list = append(list, v.(string))
+			sess.labels = append(sess.labels, v.(string))
+			return nil
+		}
+	}
+	sess.opts = opts
+	sess.multi = s.Multi
+	sess.feed = feed
+	sess.filter()
+
+	var indices []int
+	var err error
+	if isTable {
+		indices, err = sess.run(ctx, t.render)
+	} else {
+		indices, err = sess.run(ctx, renderMenu)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if isTable {
+		if !s.Multi {
+			return t.rows[indices[0]], nil
+		}
+		var res [][]any
+		for _, i := range indices {
+			res = append(res, t.rows[i])
+		}
+		return res, nil
+	}
+	if !s.Multi {
+		return list[indices[0]], nil
+	}
+	var res []string
+	for _, i := range indices {
+		res = append(res, list[i])
+	}
+	return res, nil
+}
+
+// load adds the item from the feed, and the following items which have already arrived.
+// Then the view is filtered again, keeping the cursor on the same item.
+// The feed is set to nil when it is closed.
+func (s *session) load(item streamItem, ok bool) {
+	for ok {
+		if item.err != nil {
+			s.loadErr = item.err
+		} else if err := s.add(item.value); err != nil {
+			s.loadErr = err
+		}
+		select {
+		case item, ok = <-s.feed:
+		default:
+			s.rearrange()
+			return
+		}
+	}
+	s.feed = nil
+	s.rearrange()
+}
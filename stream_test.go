@@ -0,0 +1,79 @@
+package select5_test
+
+import (
+	"errors"
+	"github.com/g1eng/select5"
+	"iter"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSelector_Select_Channel(t *testing.T) {
+	ch := make(chan string)
+	go func() {
+		ch <- "api-1"
+		ch <- "api-2"
+		// after DOWN, and before ENTER
+		time.Sleep(200 * time.Millisecond)
+		ch <- "api-0"
+	}()
+
+	// the cursor stays on the item while new items arrive, and ENTER is pressed before the source is exhausted
+	keys := [][]byte{{0x1b, '[', 'B'}, {}, {0x0a}}
+	result, _ := selectWithOutput(t, keys, (&select5.Selector{Data: (<-chan string)(ch)}).Select)
+	if result != "api-2" {
+		t.Fatalf("Expected 'api-2' to be selected, got '%v'", result)
+	}
+}
+
+func TestSelector_Select_Seq(t *testing.T) {
+	// an endless pager, which must be stopped after the selection
+	var stopped atomic.Bool
+	var rows iter.Seq[[]any] = func(yield func([]any) bool) {
+		defer stopped.Store(true)
+		for i := 0; ; i++ {
+			if !yield([]any{"page", i}) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	keys := [][]byte{{0x1b, '[', 'B'}, {0x0a}}
+	result, _ := selectWithOutput(t, keys, (&select5.Selector{Data: rows, Header: []string{"NAME", "NUM"}}).Select)
+	if !rowsEqual(result.([]any), []any{"page", 1}) {
+		t.Fatalf("Expected [page 1] to be selected, got %v", result)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if !stopped.Load() {
+		t.Fatal("the sequence is not stopped after the selection")
+	}
+}
+
+func TestSelector_Select_StreamErrors(t *testing.T) {
+	errPager := errors.New("page fetch failed")
+	var failing iter.Seq2[string, error] = func(yield func(string, error) bool) {
+		yield("", errPager)
+	}
+	empty := make(chan []any)
+	close(empty)
+
+	tests := []struct {
+		name string
+		data any
+		want error
+	}{
+		{"source error", failing, errPager},
+		{"empty source", empty, select5.ErrEmptyList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := selectWithError(t, nil, (&select5.Selector{Data: tt.data}).Select)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StructTag is the key of the struct tag for the table columns, like `select5:"Name,width=20,omitempty"`.
+//
+// The first element is the column name shown in the header (the field name if it is empty), followed by the options:
+// - width=N: truncate the cells to N characters
+// - omitempty: omit the column if the field is empty in all the rows
+//
+// A field with the tag `select5:"-"` is not shown.
+const StructTag = "select5"
+
+// structColumn describes a table column taken from a struct field
+type structColumn struct {
+	index     []int // field index for reflect.Value.FieldByIndex
+	name      string
+	width     int
+	omitEmpty bool
+}
+
+// structColumns returns the columns of the struct type, taken from the exported fields and the tags
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	var columns []structColumn
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get(StructTag)
+		if tag == "-" {
+			continue
+		}
+		c := structColumn{
+			index: f.Index,
+			name:  f.Name,
+		}
+		options := strings.Split(tag, ",")
+		if options[0] != "" {
+			c.name = options[0]
+		}
+		for _, o := range options[1:] {
+			switch {
+			case o == "omitempty":
+				c.omitEmpty = true
+			case strings.HasPrefix(o, "width="):
+				w, err := strconv.Atoi(strings.TrimPrefix(o, "width="))
+				if err != nil || w <= 0 {
+					return nil, fmt.Errorf("invalid width %q in the tag of the field %s", o, f.Name)
+				}
+				c.width = w
+			default:
+				return nil, fmt.Errorf("unknown option %q in the tag of the field %s", o, f.Name)
+			}
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no exported field in %s", t)
+	}
+	return columns, nil
+}
+
+// isStructSlice returns true if the value is a slice of structs or struct pointers
+func isStructSlice(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice {
+		return false
+	}
+	e := v.Type().Elem()
+	if e.Kind() == reflect.Pointer {
+		e = e.Elem()
+	}
+	return e.Kind() == reflect.Struct
+}
+
+// structTable creates a table from a slice of structs or struct pointers.
+// The header is taken from the column names.
+func structTable(v reflect.Value) (*table, error) {
+	if !isStructSlice(v) {
+		return nil, fmt.Errorf("%w: %s is not a slice of structs", ErrUnsupportedType, v.Type())
+	}
+	e := v.Type().Elem()
+	isPointer := e.Kind() == reflect.Pointer
+	if isPointer {
+		e = e.Elem()
+	}
+	columns, err := structColumns(e)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]any, v.Len())
+	empty := make([]bool, len(columns))
+	for j := range columns {
+		empty[j] = true
+	}
+	for i := range rows {
+		item := v.Index(i)
+		if isPointer {
+			if item.IsNil() {
+				return nil, fmt.Errorf("item %d is nil", i)
+			}
+			item = item.Elem()
+		}
+		rows[i] = make([]any, len(columns))
+		for j, c := range columns {
+			f, err := item.FieldByIndexErr(c.index)
+			if err != nil {
+				// a field promoted through a nil embedded pointer is an empty cell
+				continue
+			}
+			if !f.IsZero() {
+				empty[j] = false
+			}
+			rows[i][j] = f.Interface()
+			if _, err := cellString(rows[i][j]); err != nil {
+				return nil, fmt.Errorf("field %s: %w", c.name, err)
+			}
+		}
+	}
+
+	t := &table{column: -1, sortColumn: -1, items: func(index int) any { return v.Index(index).Interface() }}
+	var keep []int
+	for j, c := range columns {
+		if c.omitEmpty && empty[j] {
+			continue
+		}
+		keep = append(keep, j)
+		t.header = append(t.header, c.name)
+		t.widths = append(t.widths, c.width)
+	}
+	for _, row := range rows {
+		var r []any
+		for _, j := range keep {
+			r = append(r, row[j])
+		}
+		t.rows = append(t.rows, r)
+	}
+	return t, nil
+}
+
+// selectStructs presents the slice of structs or struct pointers as a table for selection.
+// It returns the selected item, or a slice of the same type for the multiple selection.
+func (s *Selector) selectStructs(ctx context.Context, v reflect.Value, opts SelectOptions) (any, error) {
+	if v.Len() == 0 {
+		return nil, fmt.Errorf("Select: %w", ErrEmptyList)
+	}
+	t, err := structTable(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Header) > 0 {
+		if len(opts.Header) != len(t.header) {
+			return nil, fmt.Errorf("header has %d columns, but the struct has %d columns", len(opts.Header), len(t.header))
+		}
+		t.header = opts.Header
+	}
+	indices, err := selectTable(ctx, t, opts, s.Multi)
+	if err != nil || indices == nil {
+		return nil, err
+	}
+	if !s.Multi {
+		return v.Index(indices[0]).Interface(), nil
+	}
+	res := reflect.MakeSlice(v.Type(), 0, len(indices))
+	for _, i := range indices {
+		res = reflect.Append(res, v.Index(i))
+	}
+	return res.Interface(), nil
+}
+
+// SelectStructRow presents a slice of structs or struct pointers as a table for selection.
+// The columns are taken from the exported fields, and configured with the StructTag.
+// It returns the selected item and its index.
+// Returns the zero value and -1 with an error if:
+// - the provided slice is empty
+// - T is not a struct or a struct pointer
+// - a field has a type which is not supported by GetVP
+// - the keyboard event channel closes
+// - the user quits (ErrCanceled)
+func SelectStructRow[T any](items []T) (T, int, error) {
+	var zero T
+	if len(items) == 0 {
+		return zero, -1, fmt.Errorf("SelectStructRow: %w", ErrEmptyList)
+	}
+	t, err := structTable(reflect.ValueOf(items))
+	if err != nil {
+		return zero, -1, err
+	}
+	indices, err := selectTable(context.Background(), t, SelectOptions{}, false)
+	if err != nil || indices == nil {
+		return zero, -1, err
+	}
+	return items[indices[0]], indices[0], nil
+}
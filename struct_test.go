@@ -0,0 +1,134 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+)
+
+type server struct {
+	Name    string  `select5:"NAME,width=8"`
+	Zone    string  `select5:"ZONE"`
+	Cost    float64 `select5:"COST"`
+	Note    *string `select5:",omitempty"`
+	Secret  string  `select5:"-"`
+	private int
+}
+
+var servers = []server{
+	{Name: "web-frontend-1", Zone: "tokyo", Cost: 3.5, Secret: "x"},
+	{Name: "web-frontend-2", Zone: "osaka", Cost: 3.2, Secret: "y"},
+	{Name: "db-1", Zone: "tokyo", Cost: 12.8, Secret: "z"},
+}
+
+func TestSelectStructRow(t *testing.T) {
+	type result struct {
+		item  server
+		index int
+	}
+	res, out := selectWithOutput(t, [][]byte{{0x1b, '[', 'A'}, {0x0a}}, func() (result, error) { // UP, ENTER
+		item, index, err := select5.SelectStructRow(servers)
+		return result{item, index}, err
+	})
+	if res.item != servers[2] || res.index != 2 {
+		t.Fatalf("Expected %v at 2 to be selected, got %v at %d", servers[2], res.item, res.index)
+	}
+	// a struct table is selected by the row, without the column cursor of the cell selection
+	if strings.Contains(out, "column:") {
+		t.Errorf("Expected no column cursor in the struct table, got %q", out)
+	}
+}
+
+func TestSelector_Select_StructPointers(t *testing.T) {
+	var data []*server
+	for i := range servers {
+		data = append(data, &servers[i])
+	}
+	list := select5.Selector{
+		Data:  data,
+		Multi: true,
+	}
+
+	// mark the first two servers with Space and DOWN
+	keys := [][]byte{{' '}, {0x1b, '[', 'B'}, {' '}, {0x0a}}
+	result, _ := selectWithOutput(t, keys, list.Select)
+	res, ok := result.([]*server)
+	if !ok {
+		t.Fatalf("Expected result to be []*server, got %T", result)
+	}
+	if len(res) != 2 || res[0] != data[0] || res[1] != data[1] {
+		t.Fatalf("Expected the first two servers, got %v", res)
+	}
+}
+
+func TestSelectStructRow_Errors(t *testing.T) {
+	type badWidth struct {
+		Name string `select5:"NAME,width=wide"`
+	}
+	type unknownOption struct {
+		Name string `select5:"NAME,bold"`
+	}
+	type unsupported struct {
+		Name string
+		Ch   chan int
+	}
+	type noField struct {
+		private string
+	}
+
+	if _, index, err := select5.SelectStructRow([]server{}); err == nil || index != -1 {
+		t.Errorf("Expected error and -1 for an empty slice, got %v and %d", err, index)
+	}
+	if _, _, err := select5.SelectStructRow([]string{"a"}); err == nil {
+		t.Error("Expected error for a non-struct slice, got none")
+	}
+	if _, _, err := select5.SelectStructRow([]badWidth{{"a"}}); err == nil || !strings.Contains(err.Error(), "invalid width") {
+		t.Errorf("Expected invalid width error, got %v", err)
+	}
+	if _, _, err := select5.SelectStructRow([]unknownOption{{"a"}}); err == nil || !strings.Contains(err.Error(), "unknown option") {
+		t.Errorf("Expected unknown option error, got %v", err)
+	}
+	if _, _, err := select5.SelectStructRow([]unsupported{{"a", nil}}); err == nil || !strings.Contains(err.Error(), "field Ch") {
+		t.Errorf("Expected unsupported field error, got %v", err)
+	}
+	if _, _, err := select5.SelectStructRow([]noField{{"a"}}); err == nil {
+		t.Error("Expected error for a struct without exported fields, got none")
+	}
+	if _, _, err := select5.SelectStructRow([]*server{nil}); err == nil {
+		t.Error("Expected error for a nil item, got none")
+	}
+}
+
+func TestSelector_Select_StructHeaderMismatch(t *testing.T) {
+	// Note is omitted as it is empty in all the rows, so the struct has 3 columns
+	list := select5.Selector{
+		Header: []string{"A", "B", "C", "D"},
+		Data:   servers,
+	}
+	_, err := list.Select()
+	if err == nil || !strings.Contains(err.Error(), "struct has 3 columns") {
+		t.Fatalf("Expected header mismatch error, got %v", err)
+	}
+}
+
+type base struct {
+	ID int
+}
+
+type outer struct {
+	Name string
+	*base
+}
+
+func TestSelector_Select_NilEmbeddedPointer(t *testing.T) {
+	data := []outer{{Name: "x"}, {Name: "y", base: &base{ID: 7}}}
+	got, out := selectWithOutput(t, [][]byte{{0x1b, '[', 'B'}, {0x0a}}, func() (any, error) {
+		return (&select5.Selector{Data: data}).Select()
+	})
+	if got.(outer).Name != "y" {
+		t.Fatalf("Expected y to be selected, got %#v", got)
+	}
+	if !strings.Contains(out, "ID") || !strings.Contains(out, "7") {
+		t.Errorf("expected the promoted field in the output %q", out)
+	}
+}
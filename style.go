@@ -0,0 +1,215 @@
+package select5
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// colorKind is the kind of a color value
+type colorKind uint8
+
+const (
+	colorDefault colorKind = iota
+	color16
+	color256
+	colorRGB
+)
+
+// Color is a foreground or background color of a Style.
+// The zero value is the default color of the terminal.
+type Color struct {
+	kind  colorKind
+	value uint32
+}
+
+// BasicColor returns one of the 16 colors: 0-7 for black, red, green, yellow, blue, magenta, cyan and white, and 8-15 for the bright ones
+func BasicColor(n uint8) Color {
+	return Color{kind: color16, value: uint32(n % 16)}
+}
+
+// IndexedColor returns one of the 256 colors of the xterm palette
+func IndexedColor(n uint8) Color {
+	return Color{kind: color256, value: uint32(n)}
+}
+
+// RGBColor returns a 24-bit color
+func RGBColor(r, g, b uint8) Color {
+	return Color{kind: colorRGB, value: uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+}
+
+// Style is a set of the text attributes
+type Style struct {
+	Fg        Color // foreground color
+	Bg        Color // background color
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+}
+
+// colorProfile is the colors supported by the terminal
+type colorProfile uint8
+
+const (
+	profileNoColor colorProfile = iota // attributes only
+	profile16
+	profile256
+	profileTrueColor
+)
+
+// detectColorProfile returns the colors supported by the terminal from the environment variables.
+// NO_COLOR disables the colors, but the other attributes are still used.
+func detectColorProfile() colorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return profileNoColor
+	}
+	termName := os.Getenv("TERM")
+	switch colorTerm := os.Getenv("COLORTERM"); {
+	case termName == "dumb":
+		return profileNoColor
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return profileTrueColor
+	case strings.Contains(termName, "256color"):
+		return profile256
+	}
+	return profile16
+}
+
+// palette16 is the RGB values of the 16 colors in xterm
+var palette16 = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels is the levels of each component in the 6x6x6 color cube of the 256 colors
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// rgb returns the RGB value of the color
+func (c Color) rgb() (r, g, b uint8) {
+	switch {
+	case c.kind == colorRGB:
+		return uint8(c.value >> 16), uint8(c.value >> 8), uint8(c.value)
+	case c.value < 16:
+		p := palette16[c.value]
+		return p[0], p[1], p[2]
+	case c.value < 232:
+		i := c.value - 16
+		return cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]
+	default:
+		v := uint8(8 + 10*(c.value-232))
+		return v, v, v
+	}
+}
+
+// distance returns the squared distance of the RGB values
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// nearestLevel returns the index of the nearest level in the color cube
+func nearestLevel(v uint8) int {
+	best := 0
+	for i, l := range cubeLevels {
+		if distance(v, 0, 0, l, 0, 0) < distance(v, 0, 0, cubeLevels[best], 0, 0) {
+			best = i
+		}
+	}
+	return best
+}
+
+// downgrade converts the color to the nearest color supported by the profile
+func (c Color) downgrade(p colorProfile) Color {
+	switch {
+	case c.kind == colorDefault || p == profileNoColor:
+		return Color{}
+	case c.kind == colorRGB && p == profile256:
+		r, g, b := c.rgb()
+		ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+		cube := IndexedColor(uint8(16 + 36*ri + 6*gi + bi))
+		gray := IndexedColor(232)
+		if avg := (int(r) + int(g) + int(b)) / 3; avg > 8 {
+			gray = IndexedColor(uint8(232 + min((avg-3)/10, 23)))
+		}
+		cr, cg, cb := cube.rgb()
+		gr, gg, gb := gray.rgb()
+		if distance(r, g, b, gr, gg, gb) < distance(r, g, b, cr, cg, cb) {
+			return gray
+		}
+		return cube
+	case c.kind != color16 && p == profile16:
+		r, g, b := c.rgb()
+		best := 0
+		for i, q := range palette16 {
+			if distance(r, g, b, q[0], q[1], q[2]) < distance(r, g, b, palette16[best][0], palette16[best][1], palette16[best][2]) {
+				best = i
+			}
+		}
+		return BasicColor(uint8(best))
+	}
+	return c
+}
+
+// params returns the SGR parameters of the color for the foreground or the background
+func (c Color) params(background bool) string {
+	switch c.kind {
+	case color16:
+		base := 30
+		if c.value >= 8 {
+			base = 90 - 8
+		}
+		if background {
+			base += 10
+		}
+		return fmt.Sprint(base + int(c.value))
+	case color256:
+		if background {
+			return fmt.Sprintf("48;5;%d", c.value)
+		}
+		return fmt.Sprintf("38;5;%d", c.value)
+	case colorRGB:
+		r, g, b := c.rgb()
+		if background {
+			return fmt.Sprintf("48;2;%d;%d;%d", r, g, b)
+		}
+		return fmt.Sprintf("38;2;%d;%d;%d", r, g, b)
+	}
+	return ""
+}
+
+// sgr returns the escape sequence to start the style with the colors supported by the profile, or "" for no style
+func (st Style) sgr(p colorProfile) string {
+	var params []string
+	for _, a := range []struct {
+		on    bool
+		param string
+	}{{st.Bold, "1"}, {st.Dim, "2"}, {st.Italic, "3"}, {st.Underline, "4"}, {st.Reverse, "7"}} {
+		if a.on {
+			params = append(params, a.param)
+		}
+	}
+	if fg := st.Fg.downgrade(p).params(false); fg != "" {
+		params = append(params, fg)
+	}
+	if bg := st.Bg.downgrade(p).params(true); bg != "" {
+		params = append(params, bg)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// resetStyle ends all the styles
+const resetStyle = "\x1b[0m"
+
+// render decorates the text with the style, or returns the text as it is for no style
+func (st Style) render(text string, p colorProfile) string {
+	sgr := st.sgr(p)
+	if sgr == "" || text == "" {
+		return text
+	}
+	return sgr + text + resetStyle
+}
@@ -0,0 +1,265 @@
+package select5
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"reflect"
+	"strings"
+)
+
+// columnSeparator is the placeholder of the column separator in the rendered table, which is replaced with the styled "|"
+const columnSeparator = "\uE000"
+
+// table keeps the data of a table selection (internal use)
+type table struct {
+	header []string
+	rows   [][]any
+	widths []int               // maximum width of each column, or 0 for no limit
+	items  func(index int) any // original items of the rows, or nil if the rows are the items
+
+	column     int  // index of the column cursor in the cell selection, or -1 for the row selection
+	sortColumn int  // index of the sort column, or -1 for the original order
+	sortDesc   bool // whether the rows are sorted in the descending order
+
+	editable bool        // whether the cells can be edited with 'e'
+	edit     *cellEditor // editor of the cell being edited, or nil
+
+	matched map[int]map[int]bool // cells matched with the filter expression, by row and column
+}
+
+// newTable creates a table with the optional header.
+// Returns an error if the header has a different number of columns from a row.
+func newTable(header []string, rows [][]any) (*table, error) {
+	if len(header) > 0 {
+		for i, row := range rows {
+			if len(row) != len(header) {
+				return nil, fmt.Errorf("header has %d columns, but row %d has %d columns", len(header), i, len(row))
+			}
+		}
+	}
+	return &table{
+		header:     header,
+		rows:       rows,
+		column:     -1,
+		sortColumn: -1,
+	}, nil
+}
+
+// item returns the original item of the row
+func (t *table) item(index int) any {
+	if t.items != nil {
+		return t.items(index)
+	}
+	return t.rows[index]
+}
+
+// minColumnWidth is the width of a column which is not shrunk any more to fit the table in the screen
+const minColumnWidth = 3
+
+// fitColumns limits the column widths to the maximum widths of the table and the ColumnWidths option,
+// and shrinks them proportionally so that a row fits in the screen width.
+func (t *table) fitColumns(s *session, widths []int) []int {
+	for j := range widths {
+		if j < len(t.widths) && t.widths[j] > 0 {
+			widths[j] = min(widths[j], t.widths[j])
+		}
+		if j < len(s.opts.ColumnWidths) && s.opts.ColumnWidths[j] > 0 {
+			widths[j] = min(widths[j], s.opts.ColumnWidths[j])
+		}
+	}
+	// each column is padded with 3 spaces, and separated by "|"
+	available := s.screenWidth() - displayWidth(s.cursorGlyph()+s.marker(-1)) - (4*len(widths) - 1)
+	return shrinkWidths(widths, available, minColumnWidth)
+}
+
+// columns returns the number of the columns
+func (t *table) columns() int {
+	n := len(t.header)
+	for _, row := range t.rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	return n
+}
+
+// columnName returns the header of the column, or the column number if the table has no header
+func (t *table) columnName(column int) string {
+	if column < len(t.header) {
+		return t.header[column]
+	}
+	return fmt.Sprintf("column %d", column+1)
+}
+
+// handleKey handles the key bindings for the table.
+// It returns true if the key is handled.
+func (t *table) handleKey(s *session, key KeyEvent) bool {
+	switch key.Key {
+	case 's':
+		t.nextSortColumn()
+	case 'r':
+		if t.sortColumn < 0 {
+			return false
+		}
+		t.sortDesc = !t.sortDesc
+	default:
+		return false
+	}
+	s.rearrange()
+	return true
+}
+
+// cellString returns the text of a table cell, which may be a value or a pointer.
+// A nil pointer is shown as an empty cell.
+func cellString(v any) (string, error) {
+	if r := reflect.ValueOf(v); r.Kind() == reflect.Pointer && r.IsNil() {
+		return "", nil
+	}
+	return GetVP(v)
+}
+
+// labels returns the text of each row, with the cells separated by a space
+func (t *table) labels() []string {
+	labels := make([]string, len(t.rows))
+	for i, row := range t.rows {
+		labels[i] = t.rowLabel(row)
+	}
+	return labels
+}
+
+// rowLabel returns the text of the row, with the cells separated by a space
+func (t *table) rowLabel(row []any) string {
+	var cells []string
+	for _, r := range row {
+		v, _ := cellString(r)
+		cells = append(cells, v)
+	}
+	return strings.Join(cells, " ")
+}
+
+// render draws the rows of the table shown in the session with the cursor and the position indicator.
+// The header is pinned at the top of the screen while the rows are scrolled.
+func (t *table) render(s *session) error {
+	var buf bytes.Buffer
+	w := tablewriter.NewWriter(&buf)
+
+	// the text of the cells and the width of each column
+	var widths []int
+	measure := func(column int, v string) {
+		for len(widths) <= column {
+			widths = append(widths, 0)
+		}
+		widths[column] = max(widths[column], displayWidth(v))
+	}
+	match := s.theme().Matched.sgr(s.profile)
+	// the cell style replaces the row style
+	cell := resetStyle + s.theme().Cell.sgr(s.profile)
+	// the caret of the editor is reversed in the cell
+	caret := resetStyle + Style{Underline: true, Reverse: !s.theme().Cell.Reverse}.sgr(s.profile)
+	cells := make([][]string, len(s.view))
+	for i, index := range s.view {
+		for j, r := range t.rows[index] {
+			v, err := s.opts.columnFormat(j).format(r)
+			if err != nil {
+				return err
+			}
+			if t.editing(index, j) {
+				v = t.edit.text(caret, cell)
+			}
+			cells[i] = append(cells[i], v)
+			measure(j, v)
+		}
+	}
+	var header []string
+	if len(t.header) > 0 {
+		header = make([]string, len(t.header))
+		copy(header, t.header)
+		if t.sortColumn >= 0 && t.sortColumn < len(header) {
+			header[t.sortColumn] += " " + t.sortMarker()
+		}
+		for j, h := range header {
+			measure(j, h)
+		}
+	}
+	widths = t.fitColumns(s, widths)
+
+	for i, index := range s.view {
+		restart := resetStyle + s.itemStyle(i, index).sgr(s.profile)
+		newRow := make([]string, len(cells[i]))
+		for j, v := range cells[i] {
+			v = truncateWidth(v, widths[j], Ellipsis)
+			switch {
+			case i == s.cursor && j == t.column:
+				v = cell + v + restart
+			case t.matched[index][j] && match != "":
+				v = match + v + restart
+			}
+			newRow[j] = v
+		}
+		w.Append(newRow)
+	}
+	if len(header) > 0 {
+		for j, h := range header {
+			header[j] = truncateWidth(h, widths[j], Ellipsis)
+		}
+		w.SetHeader(header)
+		w.SetAutoFormatHeaders(false)
+	}
+	w.SetColumnAlignment(t.alignments(s, len(widths)))
+	w.SetBorder(false)
+	w.SetColumnSeparator(columnSeparator)
+	w.SetAutoWrapText(false) // one line for each row
+	w.Render()
+
+	// no line for an empty table, which may be filled by a streaming source
+	var rendered []string
+	if buf.Len() > 0 {
+		rendered = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+
+	cursor := s.cursorGlyph()
+	footer := lines(s.opts.Footer)
+	frame := lines(s.opts.Prompt)
+	if len(t.header) > 0 {
+		// the header and the separator line
+		frame = append(frame,
+			blank(cursor+s.marker(-1))+s.styleRow(rendered[0], s.theme().Header),
+			blank(cursor+s.marker(-1))+s.theme().Border.render(rendered[1], s.profile))
+		rendered = rendered[2:]
+	}
+
+	s.scroll(s.screenHeight() - len(frame) - 1 - len(footer))
+	s.top = len(frame)
+	from, to := s.window()
+	for i := from; i < to && i < len(rendered); i++ {
+		line := blank(cursor)
+		if i == s.cursor {
+			line = cursor
+		}
+		line += s.marker(s.view[i]) + rendered[i]
+		frame = append(frame, s.styleRow(line, s.itemStyle(i, s.view[i])))
+	}
+	status := s.position()
+	if t.column >= 0 {
+		status += "  column: " + t.columnName(t.column)
+	}
+	if t.edit != nil {
+		status += "  editing"
+		if t.edit.err != nil {
+			status += fmt.Sprintf("  (%s)", t.edit.err)
+		}
+	}
+	if t.sortColumn >= 0 {
+		status += fmt.Sprintf("  sorted by %s %s", t.columnName(t.sortColumn), t.sortMarker())
+	}
+	if s.typing || len(s.query) > 0 {
+		status += "  Filter: " + string(s.query)
+	}
+	if s.err != nil {
+		status += fmt.Sprintf("  (%s)", s.err)
+	}
+	frame = append(frame, status)
+	s.draw(append(frame, footer...))
+	return nil
+}
@@ -0,0 +1,100 @@
+package select5_test
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/sys/unix"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// selectWithOutput runs the selection with the keys, and returns the result and the output.
+// The test fails if the selection returns an error.
+func selectWithOutput[T any](t *testing.T, keys [][]byte, selectFunc func() (T, error)) (T, string) {
+	t.Helper()
+	result, out, err := selectWithError(t, keys, selectFunc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result, out
+}
+
+// selectWithError runs the selection with the keys written to the stdin pipe, and returns the result,
+// the output written to the stdout pipe and the error of the selection. An empty key is a pause.
+func selectWithError[T any](t *testing.T, keys [][]byte, selectFunc func() (T, error)) (T, string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outR.Close()
+
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = r, outW
+	defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+	outCh := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, outR)
+		outCh <- buf.String()
+	}()
+
+	type answer struct {
+		result T
+		err    error
+	}
+	resultCh := make(chan answer, 1)
+	go func() {
+		result, err := selectFunc()
+		resultCh <- answer{result, err}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	for _, key := range keys {
+		w.Write(key)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var a answer
+	select {
+	case a = <-resultCh:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Test timed out waiting for selection")
+	}
+	outW.Close()
+	return a.result, <-outCh, a.err
+}
+
+// openPty opens a pseudo terminal, and returns the master and the slave.
+// The test is skipped if no pseudo terminal is available.
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo terminal: %v", err)
+	}
+	t.Cleanup(func() { master.Close() })
+	fd := int(master.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		t.Skipf("cannot unlock the pseudo terminal: %v", err)
+	}
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		t.Skipf("no pseudo terminal number: %v", err)
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("cannot open the pseudo terminal: %v", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+	return master, slave
+}
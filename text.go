@@ -0,0 +1,174 @@
+package select5
+
+import (
+	"github.com/mattn/go-runewidth"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ellipsis is appended to the truncated items and cells
+const Ellipsis = "…"
+
+// Characters which join or modify the previous character of an emoji
+const (
+	zeroWidthJoiner   = 0x200d
+	skinToneModifier  = 0x1f3fb // to 0x1f3ff
+	skinToneModifierZ = 0x1f3ff
+)
+
+// runeWidth returns the display width of the character after the previous one.
+// The emoji modifiers and the characters joined with ZWJ are shown in the cell of the previous emoji.
+func runeWidth(r rune, prev rune) int {
+	switch {
+	case r == zeroWidthJoiner, prev == zeroWidthJoiner:
+		return 0
+	case r >= skinToneModifier && r <= skinToneModifierZ:
+		return 0
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// escapeLength returns the length of the escape sequence at the beginning of the text, or 0 if it does not start with one
+func escapeLength(text string) int {
+	if len(text) < 2 || text[0] != ESC || text[1] != '[' {
+		return 0
+	}
+	j := 2
+	for j < len(text) && (text[j] < 0x40 || text[j] > 0x7e) {
+		j++
+	}
+	if j < len(text) {
+		j++
+	}
+	return j
+}
+
+// displayWidth returns the number of the terminal columns of the text.
+// East Asian wide characters and emoji take two columns, and escape sequences take none.
+func displayWidth(text string) int {
+	w := 0
+	var prev rune
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		w += runeWidth(r, prev)
+		prev = r
+		i += size
+	}
+	return w
+}
+
+// truncateWidth cuts the text to the display width with the tail (like Ellipsis) if it is wider than the width.
+// Escape sequences in the text are kept and not counted, and the style is reset before the tail.
+func truncateWidth(text string, width int, tail string) string {
+	if displayWidth(text) <= width {
+		return text
+	}
+	limit := width - displayWidth(tail)
+	if limit < 0 {
+		limit, tail = width, ""
+	}
+	var b strings.Builder
+	w := 0
+	styled := false
+	var prev rune
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			b.WriteString(text[i : i+n])
+			styled = true
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		rw := runeWidth(r, prev)
+		if w+rw > limit {
+			break
+		}
+		b.WriteString(text[i : i+size])
+		w += rw
+		prev = r
+		i += size
+	}
+	if styled {
+		b.WriteString(resetStyle)
+	}
+	b.WriteString(tail)
+	return b.String()
+}
+
+// fitWidth cuts the text at the display width and pads it with spaces to the width
+func fitWidth(text string, width int) string {
+	text = truncateWidth(text, width, "")
+	if w := displayWidth(text); w < width {
+		text += strings.Repeat(" ", width-w)
+	}
+	return text
+}
+
+// expandTabs replaces the tabs with the spaces up to the next tab stop of every 8 columns, and removes the carriage returns
+func expandTabs(line string) string {
+	if !strings.ContainsAny(line, "\t\r") {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range line {
+		switch r {
+		case '\t':
+			n := 8 - col%8
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\r':
+		default:
+			b.WriteRune(r)
+			col += runewidth.RuneWidth(r)
+		}
+	}
+	return b.String()
+}
+
+// shrinkWidths reduces the column widths proportionally to the excess of the columns over minWidth,
+// so that the sum of them fits in the total width if possible. Columns narrower than minWidth are kept.
+func shrinkWidths(widths []int, total int, minWidth int) []int {
+	res := append([]int{}, widths...)
+	sum, shrinkable := 0, 0
+	for _, w := range widths {
+		sum += w
+		if w > minWidth {
+			shrinkable += w - minWidth
+		}
+	}
+	excess := sum - total
+	if excess <= 0 || shrinkable == 0 {
+		return res
+	}
+	if excess >= shrinkable {
+		for i, w := range widths {
+			res[i] = min(w, minWidth)
+		}
+		return res
+	}
+	cut := 0
+	for i, w := range widths {
+		if w > minWidth {
+			c := excess * (w - minWidth) / shrinkable
+			res[i] -= c
+			cut += c
+		}
+	}
+	// cut the rest from the widest columns
+	for cut < excess {
+		widest := 0
+		for i := range res {
+			if res[i] > res[widest] {
+				widest = i
+			}
+		}
+		res[widest]--
+		cut++
+	}
+	return res
+}
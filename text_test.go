@@ -0,0 +1,66 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+)
+
+func TestSelectString_TruncateWideItem(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	long := strings.Repeat("日本", 30) // 120 columns
+	keys := [][]byte{{0x1b, '[', 'B'}, {0x0a}}
+	result, out := selectWithOutput(t, keys, func() (string, error) {
+		return select5.SelectString([]string{"short 😀", long})
+	})
+	if result != long {
+		t.Fatalf("Expected the long item to be selected, got '%s'", result)
+	}
+	// the line of 80 columns has the cursor, 38 wide characters and the ellipsis
+	if !strings.Contains(out, strings.Repeat("日本", 19)+select5.Ellipsis) {
+		t.Errorf("expected the truncated item in the output %q", out)
+	}
+	if strings.Contains(out, strings.Repeat("日本", 20)) {
+		t.Errorf("expected no line wider than the screen in the output %q", out)
+	}
+	if !strings.Contains(out, "short 😀") {
+		t.Errorf("expected the short item as is in the output %q", out)
+	}
+}
+
+func TestSelectOptions_ColumnWidths(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	opts := select5.SelectOptions{ColumnWidths: []int{5}}
+	keys := [][]byte{{0x0a}}
+	row, out := selectWithOutput(t, keys, func() ([]any, error) {
+		return select5.SelectTableRowWith([][]any{{"abcdefghij", "😀 emoji"}, {"ab", "x"}}, opts)
+	})
+	if row[0] != "abcdefghij" {
+		t.Fatalf("Expected the original value to be selected, got '%s'", row[0])
+	}
+	for _, s := range []string{"abcd" + select5.Ellipsis, "😀 emoji"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the output %q", s, out)
+		}
+	}
+	if strings.Contains(out, "abcdefghij") {
+		t.Errorf("expected the cell to be truncated in the output %q", out)
+	}
+}
+
+func TestSelectTableRow_ShrinkColumns(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	wide := strings.Repeat("w", 60)
+	narrow := strings.Repeat("n", 30)
+	keys := [][]byte{{0x0a}}
+	_, out := selectWithOutput(t, keys, func() ([]any, error) {
+		return select5.SelectTableRowWithHeader([]string{"WIDE", "NARROW"}, [][]any{{wide, narrow}})
+	})
+	if strings.Contains(out, wide) || strings.Contains(out, narrow) {
+		t.Fatalf("expected the columns to be shrunk in the output %q", out)
+	}
+	// both columns are shrunk in proportion to their widths
+	if !strings.Contains(out, strings.Repeat("w", 40)) || !strings.Contains(out, strings.Repeat("n", 20)+select5.Ellipsis) {
+		t.Errorf("expected proportionally shrunk columns in the output %q", out)
+	}
+}
@@ -0,0 +1,88 @@
+package select5
+
+import "strings"
+
+// Theme is the appearance of the selection.
+// Set it to the Theme option of SelectOptions, or the DefaultTheme is used.
+type Theme struct {
+	Cursor     string // cursor glyph, which is overridden by the Cursor option
+	Selected   Style  // item under the cursor
+	Unselected Style  // other items
+	Disabled   Style  // items which cannot be chosen
+	Matched    Style  // characters or cells matched with the search
+	Header     Style  // table header
+	Border     Style  // separators of the table
+	Cell       Style  // cell under the cursor in the cell selection, drawn over the row
+}
+
+// Built-in themes
+var (
+	// DefaultTheme uses the bold and reverse video for the cursor, and the gray border
+	DefaultTheme = Theme{
+		Cursor:   DefaultCursor,
+		Selected: Style{Bold: true, Reverse: true},
+		Disabled: Style{Dim: true},
+		Matched:  Style{Bold: true, Underline: true},
+		Header:   Style{Bold: true},
+		Border:   Style{Fg: BasicColor(8)},
+		Cell:     Style{Fg: BasicColor(3), Bold: true, Underline: true, Reverse: true},
+	}
+
+	// HighContrastTheme uses the bright colors on the black background for the cursor and the matches
+	HighContrastTheme = Theme{
+		Cursor:     "▶ ",
+		Selected:   Style{Fg: BasicColor(0), Bg: BasicColor(11), Bold: true},
+		Unselected: Style{Fg: BasicColor(15)},
+		Disabled:   Style{Fg: BasicColor(7), Italic: true},
+		Matched:    Style{Fg: BasicColor(14), Bold: true, Underline: true},
+		Header:     Style{Fg: BasicColor(15), Bold: true, Underline: true},
+		Border:     Style{Fg: BasicColor(15)},
+		Cell:       Style{Fg: BasicColor(15), Bg: BasicColor(9), Bold: true, Underline: true},
+	}
+
+	// MonochromeTheme uses no color
+	MonochromeTheme = Theme{
+		Cursor:   DefaultCursor,
+		Selected: Style{Reverse: true},
+		Disabled: Style{Dim: true},
+		Matched:  Style{Underline: true},
+		Header:   Style{Bold: true},
+		Cell:     Style{Bold: true, Underline: true},
+	}
+)
+
+// theme returns the theme of the selection
+func (s *session) theme() *Theme {
+	if s.opts.Theme != nil {
+		return s.opts.Theme
+	}
+	return &DefaultTheme
+}
+
+// itemStyle returns the style of the item of the original index at the view position
+func (s *session) itemStyle(i int, index int) Style {
+	switch {
+	case s.disabled(index):
+		return s.theme().Disabled
+	case i == s.cursor:
+		return s.theme().Selected
+	}
+	return s.theme().Unselected
+}
+
+// cursorGlyph returns the cursor glyph of the Cursor option, or the one of the theme
+func (s *session) cursorGlyph() string {
+	if s.opts.Cursor != "" {
+		return s.opts.Cursor
+	}
+	return s.theme().Cursor
+}
+
+// styleRow decorates the line of a table with the style, and the column separators with the border style
+func (s *session) styleRow(line string, style Style) string {
+	sep := s.theme().Border.render("|", s.profile)
+	if sep != "|" {
+		sep += style.sgr(s.profile)
+	}
+	return style.render(strings.ReplaceAll(line, columnSeparator, sep), s.profile)
+}
@@ -0,0 +1,93 @@
+package select5_test
+
+import (
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectOptions_Theme(t *testing.T) {
+	orange := &select5.Theme{
+		Cursor:   "▶ ",
+		Selected: select5.Style{Fg: select5.RGBColor(255, 135, 0), Bold: true},
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		theme    *select5.Theme
+		contains []string
+		excludes []string
+	}{
+		{"truecolor", map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"}, orange,
+			[]string{"▶ ", "\x1b[1;38;2;255;135;0m"}, nil},
+		{"256 colors", map[string]string{"TERM": "xterm-256color"}, orange,
+			[]string{"\x1b[1;38;5;208m"}, []string{"38;2;"}},
+		{"16 colors", map[string]string{"TERM": "xterm"}, orange,
+			[]string{"\x1b[1;33m"}, []string{"38;5;", "38;2;"}},
+		{"NO_COLOR", map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, orange,
+			[]string{"\x1b[1m"}, []string{"38;", "\x1b[1;33m"}},
+		{"high contrast", map[string]string{"TERM": "xterm"}, &select5.HighContrastTheme,
+			[]string{"▶ ", "\x1b[1;30;103m"}, nil},
+		{"monochrome", map[string]string{"TERM": "xterm-256color"}, &select5.MonochromeTheme,
+			[]string{"\x1b[7m"}, []string{"38;5;", "38;2;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"NO_COLOR", "COLORTERM", "TERM"} {
+				t.Setenv(k, tt.env[k])
+			}
+			opts := select5.SelectOptions{Theme: tt.theme}
+			result, out := selectWithOutput(t, [][]byte{{0x0a}}, func() (string, error) {
+				return select5.SelectStringWith([]string{"alpha", "beta"}, opts)
+			})
+			if result != "alpha" {
+				t.Fatalf("Expected 'alpha' to be selected, got '%s'", result)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(out, s) {
+					t.Errorf("expected %q in the output %q", s, out)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(out, s) {
+					t.Errorf("unexpected %q in the output %q", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectOptions_Disabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+	keys := [][]byte{
+		{0x1b, '[', 'B'}, // DOWN to the disabled item
+		{0x0a},           // ENTER is ignored
+		{0x1b, '[', 'B'}, // DOWN
+		{0x0a},
+	}
+	row, out := selectWithOutput(t, keys, func() ([]any, error) {
+		return select5.SelectTableRowWith([][]any{{"a", 1}, {"b", 2}, {"c", 3}}, select5.SelectOptions{Disabled: []int{1}})
+	})
+	if row[0] != "c" {
+		t.Fatalf("Expected 'c' to be selected, got '%s'", row[0])
+	}
+	if !strings.Contains(out, "\x1b[2m") {
+		t.Errorf("expected the disabled style in the output %q", out)
+	}
+}
+
+func TestSelectOptions_DisabledIdleTimeout(t *testing.T) {
+	opts := select5.SelectOptions{Default: 1, Disabled: []int{1}, IdleTimeout: 100 * time.Millisecond}
+	// the disabled default item is not accepted on the idle timeout
+	keys := [][]byte{{}, {}, {}, {0x1b, '[', 'B'}, {0x0a}}
+	result, _ := selectWithOutput(t, keys, func() (string, error) {
+		return select5.SelectStringWith([]string{"a", "b", "c"}, opts)
+	})
+	if result != "c" {
+		t.Fatalf("Expected 'c' to be selected, got '%s'", result)
+	}
+}
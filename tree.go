@@ -0,0 +1,189 @@
+package select5
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// breadcrumbSeparator separates the labels of the nodes in the breadcrumb line
+const breadcrumbSeparator = " › "
+
+// MenuNode is a node of a hierarchical menu for SelectTree.
+// A node with Children or Load is a branch, and the other nodes are leaves.
+type MenuNode struct {
+	Label    string                      // text shown in the menu and the breadcrumb
+	Value    any                         // value of the node for the caller
+	Children []*MenuNode                 // child nodes
+	Load     func() ([]*MenuNode, error) // loads the child nodes lazily, when the node is opened for the first time
+
+	loaded bool // whether Load has been called successfully
+}
+
+// isBranch returns true if the node has or may have children
+func (n *MenuNode) isBranch() bool {
+	return len(n.Children) > 0 || (n.Load != nil && !n.loaded)
+}
+
+// children returns the child nodes, loading them if they are not loaded yet
+func (n *MenuNode) children() ([]*MenuNode, error) {
+	if n.Load != nil && !n.loaded {
+		children, err := n.Load()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n.Label, err)
+		}
+		n.Children = children
+		n.loaded = true
+	}
+	if len(n.Children) == 0 {
+		return nil, fmt.Errorf("%s: %w", n.Label, ErrEmptyList)
+	}
+	return n.Children, nil
+}
+
+// menuTree keeps the state of a hierarchical menu (internal use)
+type menuTree struct {
+	root    *MenuNode
+	path    []*MenuNode // opened nodes below the root
+	prompt  string      // prompt given in the options, shown above the breadcrumb
+	chosen  []*MenuNode // path of the selected leaf
+	initial int         // index of the default child of the root
+}
+
+// current returns the opened node
+func (m *menuTree) current() *MenuNode {
+	if len(m.path) == 0 {
+		return m.root
+	}
+	return m.path[len(m.path)-1]
+}
+
+// show sets the children of the opened node to the session, with the cursor on the child of the index.
+// The default child of the root is accepted on the idle timeout only at the top of the tree.
+func (m *menuTree) show(s *session, index int) {
+	s.initial = -1
+	if len(m.path) == 0 {
+		s.initial = m.initial
+	}
+	children := m.current().Children
+	labels := make([]string, len(children))
+	for i, c := range children {
+		labels[i] = c.Label
+		if c.isBranch() {
+			labels[i] += "/"
+		}
+	}
+	s.labels = labels
+	s.query = nil
+	s.err = nil
+	s.filter()
+	s.moveTo(index)
+
+	var crumbs []string
+	if m.root.Label != "" {
+		crumbs = append(crumbs, m.root.Label)
+	}
+	for _, n := range m.path {
+		crumbs = append(crumbs, n.Label)
+	}
+	prompt := lines(m.prompt)
+	if len(crumbs) > 0 {
+		prompt = append(prompt, strings.Join(crumbs, breadcrumbSeparator))
+	}
+	s.opts.Prompt = strings.Join(prompt, "\n")
+}
+
+// handleSpecial handles the keys to move in the tree.
+// RIGHT and ENTER open a branch or choose a leaf, and LEFT and ESC go back to the parent.
+// It returns true if the key is handled.
+func (m *menuTree) handleSpecial(s *session, key KeyEvent) bool {
+	switch {
+	case key.Special == RIGHT || key.Special == ENTER:
+		index := s.selected()
+		if index < 0 {
+			return key.Special == RIGHT
+		}
+		node := m.current().Children[index]
+		if !node.isBranch() {
+			if key.Special == RIGHT {
+				return true
+			}
+			m.chosen = append(append([]*MenuNode{}, m.path...), node)
+			s.finish = true
+			return true
+		}
+		if _, err := node.children(); err != nil {
+			s.err = err
+			return true
+		}
+		m.path = append(m.path, node)
+		m.show(s, 0)
+		return true
+	case key.Special == LEFT || (key.Special == ESC && len(s.query) == 0):
+		if len(m.path) == 0 {
+			// ESC quits at the top of the tree
+			return key.Special == LEFT
+		}
+		parent := m.path[len(m.path)-1]
+		m.path = m.path[:len(m.path)-1]
+		index := 0
+		for i, c := range m.current().Children {
+			if c == parent {
+				index = i
+			}
+		}
+		m.show(s, index)
+		return true
+	}
+	return false
+}
+
+// SelectTree presents the children of the root for selection, and lets the user walk down the tree.
+// RIGHT or Enter opens a branch, and LEFT or ESC goes back to the parent. The path is shown in the breadcrumb line.
+// Enter on a leaf returns the path of the selected nodes from a child of the root to the leaf.
+// The children of a node with Load are loaded when the node is opened for the first time.
+// Returns an error if:
+// - the root has no children
+// - the keyboard event channel closes
+// - the user quits (ESC at the top of the tree, or Ctrl+C)
+func SelectTree(root *MenuNode) ([]*MenuNode, error) {
+	return SelectTreeContext(context.Background(), root, SelectOptions{})
+}
+
+// SelectTreeContext works like SelectTree, configured with the options,
+// and returns ctx.Err() if the context is done before the selection.
+// The IdleTimeout option accepts the default child of the root, and it is ignored if the child is a branch.
+// Below the root, the idle timeout is ignored until the user goes back to the root.
+func SelectTreeContext(ctx context.Context, root *MenuNode, opts SelectOptions) ([]*MenuNode, error) {
+	if root == nil {
+		return nil, fmt.Errorf("SelectTree: %w", ErrEmptyList)
+	}
+	if _, err := root.children(); err != nil {
+		return nil, fmt.Errorf("SelectTree: %w", err)
+	}
+	m := &menuTree{root: root, prompt: opts.Prompt}
+	s := newSession(nil)
+	s.opts = opts
+	s.filterable = true
+	s.typing = true
+	s.handleSpecial = m.handleSpecial
+	s.item = func(index int) any { return m.current().Children[index] }
+	initial := opts.defaultIndex(len(root.Children), func(i int) any { return root.Children[i].Value })
+	if root.Children[initial].isBranch() {
+		// the idle timeout chooses a leaf only
+		s.opts.IdleTimeout = 0
+	}
+	m.initial = initial
+	m.show(s, initial)
+	s.start(initial)
+
+	indices, err := s.run(ctx, renderMenu)
+	if err != nil {
+		return nil, err
+	}
+	if m.chosen == nil {
+		// the default leaf is accepted on the idle timeout
+		m.chosen = []*MenuNode{root.Children[indices[0]]}
+	}
+	return m.chosen, nil
+}
@@ -0,0 +1,113 @@
+package select5_test
+
+import (
+	"context"
+	"errors"
+	"github.com/g1eng/select5"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectTree(t *testing.T) {
+	loads := 0
+	root := &select5.MenuNode{
+		Label: "clusters",
+		Children: []*select5.MenuNode{
+			{Label: "dev", Children: []*select5.MenuNode{{Label: "default"}}},
+			{Label: "prod", Load: func() ([]*select5.MenuNode, error) {
+				loads++
+				return []*select5.MenuNode{
+					{Label: "default"},
+					{Label: "kube-system", Children: []*select5.MenuNode{
+						{Label: "coredns", Value: 53},
+						{Label: "etcd", Value: 2379},
+					}},
+				}, nil
+			}},
+			{Label: "broken", Load: func() ([]*select5.MenuNode, error) {
+				return nil, errors.New("unreachable")
+			}},
+		},
+	}
+
+	keys := [][]byte{
+		{0x1b, '[', 'B'}, {0x1b, '[', 'B'}, // DOWN to broken
+		{0x1b, '[', 'C'}, // RIGHT fails to load, and stays
+		{0x1b, '[', 'A'}, // UP to prod
+		{0x0a},           // ENTER opens prod
+		{0x1b},           // ESC goes back to the top, on prod
+		{0x1b, '[', 'C'}, // RIGHT opens prod again without loading
+		{0x1b, '[', 'B'}, // DOWN to kube-system
+		{0x1b, '[', 'C'}, // RIGHT opens kube-system
+		{0x1b, '[', 'B'}, // DOWN to etcd
+		{0x0a},           // ENTER chooses etcd
+	}
+	result, _ := selectWithOutput(t, keys, func() ([]*select5.MenuNode, error) {
+		return select5.SelectTree(root)
+	})
+	var labels []string
+	for _, n := range result {
+		labels = append(labels, n.Label)
+	}
+	if len(result) != 3 || labels[0] != "prod" || labels[1] != "kube-system" || result[2].Value != 2379 {
+		t.Fatalf("Expected the path prod › kube-system › etcd, got %v", labels)
+	}
+	if loads != 1 {
+		t.Fatalf("Expected the children to be loaded once, got %d", loads)
+	}
+}
+
+func TestSelectTree_Errors(t *testing.T) {
+	if _, err := select5.SelectTree(&select5.MenuNode{Label: "empty"}); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("expected ErrEmptyList, got %v", err)
+	}
+	if _, err := select5.SelectTree(nil); !errors.Is(err, select5.ErrEmptyList) {
+		t.Errorf("expected ErrEmptyList, got %v", err)
+	}
+}
+
+func TestSelectTree_IdleTimeout(t *testing.T) {
+	root := &select5.MenuNode{Children: []*select5.MenuNode{{Label: "a"}, {Label: "b", Value: 2}}}
+	opts := select5.SelectOptions{Default: 1, IdleTimeout: 100 * time.Millisecond}
+	path, _ := selectWithOutput(t, nil, func() ([]*select5.MenuNode, error) {
+		return select5.SelectTreeContext(context.Background(), root, opts)
+	})
+	if len(path) != 1 || path[0].Value != 2 {
+		t.Fatalf("Expected the default leaf b to be accepted, got %v", path)
+	}
+
+	// the idle timeout is ignored for a branch
+	root = &select5.MenuNode{Children: []*select5.MenuNode{
+		{Label: "dir", Children: []*select5.MenuNode{{Label: "file", Value: 1}}},
+	}}
+	keys := [][]byte{{}, {}, {0x1b, '[', 'C'}, {0x0a}}
+	path, _ = selectWithOutput(t, keys, func() ([]*select5.MenuNode, error) {
+		return select5.SelectTreeContext(context.Background(), root, select5.SelectOptions{IdleTimeout: 100 * time.Millisecond})
+	})
+	if len(path) != 2 || path[1].Value != 1 {
+		t.Fatalf("Expected the path dir › file, got %v", path)
+	}
+}
+
+func TestSelectTree_IdleTimeoutBelowRoot(t *testing.T) {
+	root := &select5.MenuNode{Children: []*select5.MenuNode{
+		{Label: "a"},
+		{Label: "dir", Children: []*select5.MenuNode{{Label: "file"}, {Label: "other"}}},
+	}}
+	opts := select5.SelectOptions{IdleTimeout: 250 * time.Millisecond, Summary: true}
+	keys := [][]byte{
+		{0x1b, '[', 'B'}, {0x1b, '[', 'C'}, // DOWN, RIGHT opens dir
+		{}, {}, {}, {}, {}, // the idle timeout is ignored below the root
+		{0x1b, '[', 'B'}, {0x0a}, // DOWN, ENTER chooses other
+	}
+	path, out := selectWithOutput(t, keys, func() ([]*select5.MenuNode, error) {
+		return select5.SelectTreeContext(context.Background(), root, opts)
+	})
+	if len(path) != 2 || path[0].Label != "dir" || path[1].Label != "other" {
+		t.Fatalf("Expected the path dir › other, got %v", path)
+	}
+	if !strings.Contains(out, "✔ picked: other") {
+		t.Errorf("Expected the summary of the chosen leaf, got %q", out)
+	}
+}
@@ -0,0 +1,87 @@
+package select5
+
+import (
+	"fmt"
+	"golang.org/x/term"
+	"os"
+)
+
+// Default terminal size used when the output is not a terminal
+const (
+	defaultTerminalWidth  = 80
+	defaultTerminalHeight = 24
+)
+
+// terminalSize returns the width and height of the terminal
+func terminalSize() (width int, height int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return defaultTerminalWidth, defaultTerminalHeight
+	}
+	return width, height
+}
+
+// scroll sets the page height and moves the window of the view so that the cursor is visible
+func (s *session) scroll(height int) {
+	if height < 1 {
+		height = 1
+	}
+	s.page = height
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	} else if s.cursor >= s.offset+height {
+		s.offset = s.cursor - height + 1
+	}
+	if max := len(s.view) - height; s.offset > max {
+		s.offset = max
+	}
+	if s.offset < 0 {
+		s.offset = 0
+	}
+}
+
+// window returns the range of the view positions shown in the page
+func (s *session) window() (from int, to int) {
+	to = s.offset + s.page
+	if to > len(s.view) {
+		to = len(s.view)
+	}
+	return s.offset, to
+}
+
+// jump moves the cursor by delta without wrapping around the view
+func (s *session) jump(delta int) {
+	if len(s.view) == 0 {
+		return
+	}
+	s.cursor += delta
+	if s.cursor < 0 {
+		s.cursor = 0
+	} else if s.cursor >= len(s.view) {
+		s.cursor = len(s.view) - 1
+	}
+}
+
+// pageSize returns the number of items moved with PAGEUP or PAGEDOWN
+func (s *session) pageSize() int {
+	if s.page < 1 {
+		return 1
+	}
+	return s.page
+}
+
+// position returns the position indicator of the cursor, like "12/200".
+// The loading indicator and the error of a streaming source follow it.
+func (s *session) position() string {
+	pos := "0/0"
+	if len(s.view) > 0 {
+		pos = fmt.Sprintf("%d/%d", s.cursor+1, len(s.view))
+	}
+	if s.feed != nil {
+		pos += " " + loadingFrames[s.frame%len(loadingFrames)] + " loading"
+	}
+	if s.loadErr != nil {
+		pos += fmt.Sprintf("  (%s)", s.loadErr)
+	}
+	return pos
+}